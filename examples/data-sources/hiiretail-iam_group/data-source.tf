@@ -0,0 +1,9 @@
+# Look up a group owned by another team by its name
+data "hiiretail-iam_group" "platform" {
+  name = "platform-team"
+}
+
+resource "hiiretail-iam_group_member" "on_call" {
+  group_id = data.hiiretail-iam_group.platform.id
+  user_id  = "user-123456"
+}
@@ -0,0 +1,13 @@
+# Grant the cashier role to every store staff group
+data "hiiretail-iam_groups" "store_staff" {
+  name_prefix = "store-"
+  name_regex  = "-staff$"
+}
+
+resource "hiiretail-iam_role_binding" "cashier" {
+  for_each = toset(data.hiiretail-iam_groups.store_staff.ids)
+
+  group_id = each.value
+  role_id  = hiiretail-iam_role.cashier.id
+  bindings = ["bu:001"]
+}
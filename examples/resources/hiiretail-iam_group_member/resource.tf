@@ -0,0 +1,5 @@
+# Additive membership: other members of the group are left untouched
+resource "hiiretail-iam_group_member" "developer" {
+  group_id = hiiretail-iam_group.developers.id
+  user_id  = "user-123456"
+}
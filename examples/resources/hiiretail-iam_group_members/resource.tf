@@ -0,0 +1,8 @@
+# Authoritative member list: anyone not listed is removed from the group
+resource "hiiretail-iam_group_members" "admins" {
+  group_id = hiiretail-iam_group.admins.id
+  members = [
+    "user-123456",
+    "user-234567",
+  ]
+}
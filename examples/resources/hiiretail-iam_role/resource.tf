@@ -0,0 +1,9 @@
+# Custom role bundling point of sale permissions
+resource "hiiretail-iam_role" "cashier" {
+  title       = "Cashier"
+  description = "Point of sale cashier"
+  permissions = [
+    "pos.receipt.read",
+    "pos.receipt.create",
+  ]
+}
@@ -0,0 +1,9 @@
+# Grant the cashier role to the store staff group in two business units
+resource "hiiretail-iam_role_binding" "store_staff_cashier" {
+  group_id = hiiretail-iam_group.store_staff.id
+  role_id  = hiiretail-iam_role.cashier.id
+  bindings = [
+    "bu:001",
+    "bu:002",
+  ]
+}
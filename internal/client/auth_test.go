@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientCredentialsTokenSource_CachesAndRefreshes(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, http.MethodPost, r.Method)
+		clientID, clientSecret, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "my-client", clientID)
+		assert.Equal(t, "my-secret", clientSecret)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "iam:read iam:write", r.PostForm.Get("scope"))
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token": "token-%d", "token_type": "Bearer", "expires_in": 300}`, requests)
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	ts := NewClientCredentialsTokenSource(ClientCredentialsConfig{
+		ClientID:     "my-client",
+		ClientSecret: "my-secret",
+		TokenURL:     srv.URL,
+		Scopes:       []string{"iam:read", "iam:write"},
+	}, nil).(*clientCredentialsTokenSource)
+	ts.now = func() time.Time { return now }
+
+	token, err := ts.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// A valid token is served from the cache
+	now = now.Add(4 * time.Minute)
+	token, err = ts.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+	assert.Equal(t, 1, requests)
+
+	// A token close to expiry is refreshed
+	now = now.Add(40 * time.Second)
+	token, err = ts.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+	assert.Equal(t, 2, requests)
+}
+
+func TestClientCredentialsTokenSource_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error": "invalid_client"}`))
+	}))
+	defer srv.Close()
+
+	ts := NewClientCredentialsTokenSource(ClientCredentialsConfig{
+		ClientID:     "my-client",
+		ClientSecret: "wrong-secret",
+		TokenURL:     srv.URL,
+	}, nil)
+
+	_, err := ts.Token(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "status 401")
+}
+
+func TestClient_UsesTokenSource(t *testing.T) {
+	tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "oauth-token", "token_type": "Bearer", "expires_in": 3600}`))
+	}))
+	defer tokenSrv.Close()
+
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer oauth-token", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer apiSrv.Close()
+
+	ts := NewClientCredentialsTokenSource(ClientCredentialsConfig{
+		ClientID:     "my-client",
+		ClientSecret: "my-secret",
+		TokenURL:     tokenSrv.URL,
+	}, nil)
+	client := NewClient(apiSrv.URL, "", WithTokenSource(ts))
+
+	err := client.DeleteGroup(context.Background(), "test-id", "")
+	assert.NoError(t, err)
+}
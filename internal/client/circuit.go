@@ -0,0 +1,163 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrServiceUnavailable is returned without contacting the API while the
+// circuit breaker is open. It is never retried.
+var ErrServiceUnavailable = errors.New("IAM API unavailable")
+
+// CircuitBreakerConfig configures when the client stops sending requests to
+// a failing API
+type CircuitBreakerConfig struct {
+	// Threshold is the number of consecutive failed attempts that opens the
+	// circuit. Zero disables the circuit breaker.
+	Threshold int
+	// CoolDown is how long the circuit stays open before a single probe
+	// request is let through to check whether the API has recovered
+	CoolDown time.Duration
+}
+
+// DefaultCircuitBreakerConfig opens the circuit after five consecutive
+// failures and probes the API every 30 seconds
+var DefaultCircuitBreakerConfig = CircuitBreakerConfig{
+	Threshold: 5,
+	CoolDown:  30 * time.Second,
+}
+
+// circuitState is the state of a circuit breaker
+type circuitState int
+
+const (
+	// circuitClosed lets all requests through
+	circuitClosed circuitState = iota
+	// circuitOpen fails all requests until the cool-down has passed
+	circuitOpen
+	// circuitHalfOpen lets a single probe request through
+	circuitHalfOpen
+)
+
+func (s circuitState) String() string {
+	switch s {
+	case circuitOpen:
+		return "open"
+	case circuitHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// circuitOpenError reports that a request was refused by an open circuit
+type circuitOpenError struct {
+	failures int
+	last     string
+	retryAt  time.Time
+}
+
+func (e *circuitOpenError) Error() string {
+	return fmt.Sprintf("%s: %d consecutive requests failed (last: %s); not sending requests until %s",
+		ErrServiceUnavailable, e.failures, e.last, e.retryAt.Format(time.RFC3339))
+}
+
+func (e *circuitOpenError) Unwrap() error {
+	return ErrServiceUnavailable
+}
+
+// circuitBreaker counts consecutive server errors and network failures across
+// all requests of a client. Once Threshold is reached, requests fail fast
+// with ErrServiceUnavailable instead of running their retry schedule against
+// an API that is down. After CoolDown one probe is let through: its success
+// closes the circuit, its failure opens it for another CoolDown.
+type circuitBreaker struct {
+	mu     sync.Mutex
+	config CircuitBreakerConfig
+	clk    clock
+
+	state    circuitState
+	failures int
+	last     string
+	openedAt time.Time
+	probing  bool
+}
+
+// newCircuitBreaker returns a closed circuit breaker
+func newCircuitBreaker(config CircuitBreakerConfig, clk clock) *circuitBreaker {
+	return &circuitBreaker{config: config, clk: clk}
+}
+
+// allow reports whether a request may be sent, moving an open circuit to
+// half-open once the cool-down has passed
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.config.Threshold <= 0 {
+		return nil
+	}
+
+	retryAt := b.openedAt.Add(b.config.CoolDown)
+	switch b.state {
+	case circuitOpen:
+		if b.clk.Now().Before(retryAt) {
+			return &circuitOpenError{failures: b.failures, last: b.last, retryAt: retryAt}
+		}
+		b.state = circuitHalfOpen
+		b.probing = true
+		return nil
+	case circuitHalfOpen:
+		// Only one probe at a time
+		if b.probing {
+			return &circuitOpenError{failures: b.failures, last: b.last, retryAt: retryAt}
+		}
+		b.probing = true
+		return nil
+	}
+
+	return nil
+}
+
+// record updates the circuit with the outcome of a request that allow let
+// through, returning the new state if it changed. Only server errors and
+// network failures count as failures; client errors such as 404 or 429, and
+// 501 for an unsupported method, show that the API is up.
+func (b *circuitBreaker) record(resp *http.Response, err error) (circuitState, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.config.Threshold <= 0 {
+		return b.state, false
+	}
+
+	previous := b.state
+	b.probing = false
+
+	if err == nil && (resp.StatusCode < http.StatusInternalServerError || resp.StatusCode == http.StatusNotImplemented) {
+		b.state = circuitClosed
+		b.failures = 0
+		return b.state, b.state != previous
+	}
+
+	b.failures++
+	b.last = describeFailure(resp, err)
+	if b.state == circuitHalfOpen || b.failures >= b.config.Threshold {
+		b.state = circuitOpen
+		b.openedAt = b.clk.Now()
+	}
+
+	return b.state, b.state != previous
+}
+
+// release gives up the probe of a half-open circuit without recording an
+// outcome, e.g. when the caller's context was cancelled
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
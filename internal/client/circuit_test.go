@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker_OpensAfterThreshold(t *testing.T) {
+	clk := newFakeClock()
+	b := newCircuitBreaker(CircuitBreakerConfig{Threshold: 3, CoolDown: time.Minute}, clk)
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, b.allow())
+		b.record(response(503, nil), nil)
+	}
+	assert.NoError(t, b.allow())
+	state, changed := b.record(nil, errors.New("connection refused"))
+	assert.Equal(t, circuitOpen, state)
+	assert.True(t, changed)
+
+	err := b.allow()
+	assert.ErrorIs(t, err, ErrServiceUnavailable)
+	assert.ErrorContains(t, err, "3 consecutive requests failed (last: connection refused)")
+}
+
+func TestCircuitBreaker_SuccessResetsCount(t *testing.T) {
+	clk := newFakeClock()
+	b := newCircuitBreaker(CircuitBreakerConfig{Threshold: 2, CoolDown: time.Minute}, clk)
+
+	b.record(response(502, nil), nil)
+	// Client errors show the API is up
+	b.record(response(404, nil), nil)
+	b.record(response(502, nil), nil)
+
+	assert.NoError(t, b.allow())
+}
+
+func TestCircuitBreaker_HalfOpen(t *testing.T) {
+	clk := newFakeClock()
+	b := newCircuitBreaker(CircuitBreakerConfig{Threshold: 1, CoolDown: time.Minute}, clk)
+	b.record(response(503, nil), nil)
+
+	// After the cool-down a single probe is let through
+	clk.now = clk.now.Add(time.Minute)
+	assert.NoError(t, b.allow())
+	assert.ErrorIs(t, b.allow(), ErrServiceUnavailable)
+
+	// A failed probe opens the circuit for another cool-down
+	state, _ := b.record(response(503, nil), nil)
+	assert.Equal(t, circuitOpen, state)
+	clk.now = clk.now.Add(30 * time.Second)
+	assert.ErrorIs(t, b.allow(), ErrServiceUnavailable)
+
+	// A successful probe closes it
+	clk.now = clk.now.Add(30 * time.Second)
+	assert.NoError(t, b.allow())
+	state, changed := b.record(response(200, nil), nil)
+	assert.Equal(t, circuitClosed, state)
+	assert.True(t, changed)
+	assert.NoError(t, b.allow())
+	assert.NoError(t, b.allow())
+}
+
+func TestCircuitBreaker_ReleaseProbe(t *testing.T) {
+	clk := newFakeClock()
+	b := newCircuitBreaker(CircuitBreakerConfig{Threshold: 1, CoolDown: time.Minute}, clk)
+	b.record(response(503, nil), nil)
+
+	clk.now = clk.now.Add(time.Minute)
+	assert.NoError(t, b.allow())
+	b.release()
+
+	// The abandoned probe does not block the next one
+	assert.NoError(t, b.allow())
+}
+
+func TestCircuitBreaker_Disabled(t *testing.T) {
+	b := newCircuitBreaker(CircuitBreakerConfig{}, newFakeClock())
+
+	for i := 0; i < 10; i++ {
+		b.record(response(503, nil), nil)
+	}
+	assert.NoError(t, b.allow())
+}
+
+func TestClient_CircuitBreakerFailsFast(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	clk := newFakeClock()
+	client := NewClient(srv.URL, "test-token")
+	client.retry = RetryConfig{MaxRetries: 5, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, Multiplier: 1}
+	client.breaker = newCircuitBreaker(CircuitBreakerConfig{Threshold: 3, CoolDown: time.Minute}, clk)
+
+	// The circuit opens during the first request's retries, which stop there
+	_, err := client.GetGroup(context.Background(), "test-id")
+	assert.ErrorIs(t, err, ErrServiceUnavailable)
+	assert.Equal(t, 3, requests)
+
+	// Later requests fail without reaching the API
+	err = client.DeleteGroup(context.Background(), "test-id", "")
+	assert.ErrorIs(t, err, ErrServiceUnavailable)
+	assert.ErrorContains(t, err, "IAM API unavailable: 3 consecutive requests failed (last: status 503)")
+	assert.Equal(t, 3, requests)
+}
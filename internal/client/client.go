@@ -1,43 +1,83 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// TenantHeader is the request header carrying the tenant ID
+const TenantHeader = "X-Tenant-ID"
+
+// IdempotencyKeyHeader is the request header carrying the idempotency key of
+// a create request. The key is generated once per logical operation and
+// resent unchanged on every retry, so the API can deduplicate them.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// MergePatchContentType is the content type of JSON merge patch (RFC 7396)
+// request bodies
+const MergePatchContentType = "application/merge-patch+json"
+
 // IClient is an interface for the HiiRetail IAM API client.
-// It provides methods for managing IAM groups, including CRUD operations
-// with proper error handling, retry logic, and timeout management.
+// It provides methods for managing IAM groups, their members, custom roles and
+// the roles granted to groups, including CRUD operations with proper error
+// handling, retry logic, and timeout management.
 type IClient interface {
 	CreateGroup(ctx context.Context, name string, description string) (*Group, error)
 	GetGroup(ctx context.Context, id string) (*Group, error)
-	UpdateGroup(ctx context.Context, id string, name string, description string) (*Group, error)
-	DeleteGroup(ctx context.Context, id string) error
+	UpdateGroup(ctx context.Context, id string, name string, description string, etag string) (*Group, error)
+	PatchGroup(ctx context.Context, id string, patch GroupPatch, etag string) (*Group, error)
+	DeleteGroup(ctx context.Context, id string, etag string) error
+	ListGroups(ctx context.Context, opts ListGroupsOptions) ([]Group, error)
+	FindGroupsByName(ctx context.Context, name string) ([]Group, error)
+	CreateRole(ctx context.Context, title string, description string, permissions []string) (*Role, error)
+	GetRole(ctx context.Context, id string) (*Role, error)
+	UpdateRole(ctx context.Context, id string, title string, description string, permissions []string, etag string) (*Role, error)
+	DeleteRole(ctx context.Context, id string, etag string) error
+	ListRoleBindings(ctx context.Context, groupID string) ([]RoleBinding, error)
+	AddRoleBinding(ctx context.Context, groupID string, binding RoleBinding) (*RoleBinding, error)
+	RemoveRoleBinding(ctx context.Context, groupID string, roleID string) error
+	ListGroupMembers(ctx context.Context, groupID string) ([]GroupMember, error)
+	AddGroupMember(ctx context.Context, groupID string, userID string) error
+	RemoveGroupMember(ctx context.Context, groupID string, userID string) error
+	TenantID() string
 }
 
 // Client represents the HiiRetail IAM API client
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
-	token      string
+	tokens     TokenSource
+	tenantID   string
 	retry      RetryConfig
-	logger     *Logger
 	timeouts   TimeoutConfig
+	limiter    *rateLimiter
+	breaker    *circuitBreaker
+
+	// sensitiveLogFields and sensitiveLogValues are masked in log output
+	sensitiveLogFields []string
+	sensitiveLogValues []string
 }
 
 // NewClient creates a new HiiRetail IAM API client with default configurations for:
 // - HTTP client settings
 // - Retry behavior
-// - Logging (INFO level)
+// - Structured logging through the tflog subsystem LogSubsystem
 // - Operation timeouts
 //
 // Parameters:
 //   - baseURL: The base URL of the HiiRetail IAM API (e.g., "https://api.hiiretail.com/v1")
-//   - token: The authentication token for API requests
+//   - token: A static authentication token for API requests
+//   - opts: Optional settings, e.g. WithTokenSource to use OAuth2 client credentials
 //
 // The client automatically handles:
 //   - Authentication via Bearer token
@@ -45,17 +85,72 @@ type Client struct {
 //   - Logging of requests and responses
 //   - Proper timeout management
 //   - Error wrapping and status code handling
-func NewClient(baseURL string, token string) *Client {
-	return &Client{
+func NewClient(baseURL string, token string, opts ...Option) *Client {
+	c := &Client{
 		baseURL:    strings.TrimSuffix(baseURL, "/"),
 		httpClient: &http.Client{},
-		token:      token,
+		tokens:     staticTokenSource(token),
 		retry:      DefaultRetryConfig,
-		logger:     NewLogger(LogLevelInfo),
 		timeouts:   DefaultTimeoutConfig,
+		limiter:    newRateLimiter(0, realClock{}),
+		breaker:    newCircuitBreaker(DefaultCircuitBreakerConfig, realClock{}),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Option configures optional Client settings
+type Option func(*Client)
+
+// WithTokenSource makes the client obtain its access tokens from ts instead
+// of the static token passed to NewClient
+func WithTokenSource(ts TokenSource) Option {
+	return func(c *Client) {
+		c.tokens = ts
 	}
 }
 
+// WithTenantID sends the given tenant ID with every request
+func WithTenantID(tenantID string) Option {
+	return func(c *Client) {
+		c.tenantID = tenantID
+	}
+}
+
+// WithRetryConfig replaces DefaultRetryConfig as the retry behavior of the client
+func WithRetryConfig(config RetryConfig) Option {
+	return func(c *Client) {
+		c.retry = config
+	}
+}
+
+// WithRateLimit limits the client to the given number of requests per second,
+// shared by all callers of the client. Zero means no limit beyond the one the
+// API reports in its rate limit headers.
+func WithRateLimit(requestsPerSecond float64) Option {
+	return func(c *Client) {
+		c.limiter = newRateLimiter(requestsPerSecond, realClock{})
+	}
+}
+
+// WithCircuitBreaker replaces DefaultCircuitBreakerConfig as the circuit
+// breaker settings of the client
+func WithCircuitBreaker(config CircuitBreakerConfig) Option {
+	return func(c *Client) {
+		c.breaker = newCircuitBreaker(config, realClock{})
+	}
+}
+
+// TenantID returns the tenant the client is configured for, or an empty
+// string if no tenant was configured
+func (c *Client) TenantID() string {
+	return c.tenantID
+}
+
 // Group represents an IAM group in the HiiRetail system.
 // It contains the core attributes that identify and describe a group.
 //
@@ -63,15 +158,18 @@ func NewClient(baseURL string, token string) *Client {
 //   - ID: Unique identifier for the group (generated by the API)
 //   - Name: Human-readable name for the group (must be unique)
 //   - Description: Optional description explaining the group's purpose
+//   - ETag: Version of the group as returned by the API, if any
 type Group struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	ETag        string `json:"-"`
 }
 
 // CreateGroup creates a new IAM group
 func (c *Client) CreateGroup(ctx context.Context, name string, description string) (*Group, error) {
 	var result *Group
+	var retried bool
 	err := withTimeout(ctx, c.timeouts.Create, func(ctx context.Context) error {
 		payload := map[string]interface{}{
 			"name":        name,
@@ -89,7 +187,9 @@ func (c *Client) CreateGroup(ctx context.Context, name string, description strin
 		}
 
 		var group Group
-		if err := c.do(req, &group); err != nil {
+		attempts, err := c.doAttempts(req, &group)
+		retried = attempts > 1
+		if err != nil {
 			return err
 		}
 
@@ -97,6 +197,19 @@ func (c *Client) CreateGroup(ctx context.Context, name string, description strin
 		return nil
 	})
 
+	if IsConflict(err) && retried {
+		// A retried create may conflict with the group its first attempt
+		// already created; adopt that group rather than failing. A conflict
+		// on the first attempt is someone else's group and is returned.
+		if group := c.findCreatedGroup(ctx, name, description); group != nil {
+			tflog.SubsystemInfo(c.logContext(ctx, nil), LogSubsystem, "Group already exists after a conflicting create, using it", map[string]interface{}{
+				"name": name,
+				"id":   group.ID,
+			})
+			return group, nil
+		}
+	}
+
 	if err != nil {
 		return nil, err
 	}
@@ -104,6 +217,32 @@ func (c *Client) CreateGroup(ctx context.Context, name string, description strin
 	return result, nil
 }
 
+// findCreatedGroup returns the single group matching name and description,
+// or nil if there is no such group or the match is ambiguous
+func (c *Client) findCreatedGroup(ctx context.Context, name string, description string) *Group {
+	groups, err := c.FindGroupsByName(ctx, name)
+	if err != nil {
+		tflog.SubsystemError(c.logContext(ctx, nil), LogSubsystem, "Failed to look up group after a conflicting create", map[string]interface{}{
+			"name":  name,
+			"error": err.Error(),
+		})
+		return nil
+	}
+
+	var match *Group
+	for i := range groups {
+		if groups[i].Description != description {
+			continue
+		}
+		if match != nil {
+			return nil
+		}
+		match = &groups[i]
+	}
+
+	return match
+}
+
 // GetGroup retrieves an IAM group by ID
 func (c *Client) GetGroup(ctx context.Context, id string) (*Group, error) {
 	var result *Group
@@ -129,8 +268,27 @@ func (c *Client) GetGroup(ctx context.Context, id string) (*Group, error) {
 	return result, nil
 }
 
-// UpdateGroup updates an existing IAM group
-func (c *Client) UpdateGroup(ctx context.Context, id string, name string, description string) (*Group, error) {
+// FindGroupsByName returns all IAM groups whose name is exactly name
+func (c *Client) FindGroupsByName(ctx context.Context, name string) ([]Group, error) {
+	groups, err := c.ListGroups(ctx, ListGroupsOptions{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	// The API may match names loosely, so only keep exact matches
+	var result []Group
+	for _, group := range groups {
+		if group.Name == name {
+			result = append(result, group)
+		}
+	}
+
+	return result, nil
+}
+
+// UpdateGroup updates an existing IAM group. If etag is set, the update is
+// only applied if the group has not changed since that version was read.
+func (c *Client) UpdateGroup(ctx context.Context, id string, name string, description string, etag string) (*Group, error) {
 	var result *Group
 	err := withTimeout(ctx, c.timeouts.Update, func(ctx context.Context) error {
 		payload := map[string]interface{}{
@@ -147,6 +305,55 @@ func (c *Client) UpdateGroup(ctx context.Context, id string, name string, descri
 		if err != nil {
 			return err
 		}
+		setIfMatch(req, etag)
+
+		var group Group
+		if err := c.do(req, &group); err != nil {
+			return err
+		}
+
+		result = &group
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GroupPatch holds the group attributes to change in a PatchGroup call.
+// Nil fields are left as they are.
+type GroupPatch struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// IsEmpty reports whether the patch changes nothing
+func (p GroupPatch) IsEmpty() bool {
+	return p.Name == nil && p.Description == nil
+}
+
+// PatchGroup changes only the attributes set in patch, as a JSON merge patch,
+// leaving fields the provider does not manage untouched. If etag is set, the
+// patch is only applied if the group has not changed since that version was
+// read. Servers without PATCH support answer with an error for which
+// IsNotSupported is true.
+func (c *Client) PatchGroup(ctx context.Context, id string, patch GroupPatch, etag string) (*Group, error) {
+	var result *Group
+	err := withTimeout(ctx, c.timeouts.Update, func(ctx context.Context) error {
+		data, err := json.Marshal(patch)
+		if err != nil {
+			return fmt.Errorf("failed to marshal group patch: %w", err)
+		}
+
+		req, err := c.newRequest(ctx, http.MethodPatch, fmt.Sprintf("/groups/%s", id), strings.NewReader(string(data)))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", MergePatchContentType)
+		setIfMatch(req, etag)
 
 		var group Group
 		if err := c.do(req, &group); err != nil {
@@ -164,13 +371,15 @@ func (c *Client) UpdateGroup(ctx context.Context, id string, name string, descri
 	return result, nil
 }
 
-// DeleteGroup deletes an IAM group
-func (c *Client) DeleteGroup(ctx context.Context, id string) error {
+// DeleteGroup deletes an IAM group. If etag is set, the group is only deleted
+// if it has not changed since that version was read.
+func (c *Client) DeleteGroup(ctx context.Context, id string, etag string) error {
 	return withTimeout(ctx, c.timeouts.Delete, func(ctx context.Context) error {
 		req, err := c.newRequest(ctx, http.MethodDelete, fmt.Sprintf("/groups/%s", id), nil)
 		if err != nil {
 			return err
 		}
+		setIfMatch(req, etag)
 
 		return c.do(req, nil)
 	})
@@ -183,83 +392,199 @@ func (c *Client) newRequest(ctx context.Context, method string, path string, bod
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.token)
+	token, err := c.tokens.Token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain access token: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	if c.tenantID != "" {
+		req.Header.Set(TenantHeader, c.tenantID)
+	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
+	if method == http.MethodPost {
+		key, err := newIdempotencyKey()
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+
 	return req, nil
 }
 
+// recordOutcome reports the result of an attempt to the circuit breaker and
+// logs any change of its state
+func (c *Client) recordOutcome(ctx context.Context, resp *http.Response, err error) {
+	state, changed := c.breaker.record(resp, err)
+	if !changed {
+		return
+	}
+
+	switch state {
+	case circuitOpen:
+		tflog.SubsystemError(ctx, LogSubsystem, "Circuit breaker opened after repeated failures, failing requests fast", map[string]interface{}{
+			"last_failure": describeFailure(resp, err),
+			"cool_down":    c.breaker.config.CoolDown.String(),
+		})
+	case circuitClosed:
+		tflog.SubsystemInfo(ctx, LogSubsystem, "Circuit breaker closed, the IAM API has recovered")
+	}
+}
+
+// resourcePath returns the segments of the request path below the base URL
+func (c *Client) resourcePath(req *http.Request) []string {
+	path := req.URL.Path
+	if base, err := url.Parse(c.baseURL); err == nil {
+		path = strings.TrimPrefix(path, strings.TrimSuffix(base.Path, "/"))
+	}
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
 // do performs an HTTP request and decodes the response
 func (c *Client) do(req *http.Request, v interface{}) error {
+	_, err := c.doAttempts(req, v)
+	return err
+}
+
+// doAttempts is do, also returning the number of attempts made, so callers
+// can tell whether an earlier attempt may have taken effect
+func (c *Client) doAttempts(req *http.Request, v interface{}) (int, error) {
 	var attemptCount = 0
-	
+	ctx := c.logContext(req.Context(), req)
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"path":   req.URL.Path,
+	}
+
+	// Buffer the payload once so every attempt sends the same bytes
+	var payload []byte
+	if req.Body != nil {
+		var err error
+		payload, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			tflog.SubsystemError(ctx, LogSubsystem, "Failed to read request body", fields, map[string]interface{}{"error": err.Error()})
+			return attemptCount, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
 	resp, err := withRetry(req.Context(), c.retry, func() (*http.Response, error) {
 		attemptCount++
-		// Create a new request for each retry since the body might need to be re-read
-		newReq, err := c.newRequest(req.Context(), req.Method, strings.TrimPrefix(req.URL.Path, c.baseURL), nil)
+		attempt := map[string]interface{}{"attempt": attemptCount}
+		if err := c.breaker.allow(); err != nil {
+			tflog.SubsystemError(ctx, LogSubsystem, "Request refused by the circuit breaker", fields, attempt, map[string]interface{}{"error": err.Error()})
+			return nil, err
+		}
+
+		stats, err := c.limiter.wait(req.Context())
 		if err != nil {
-			c.logger.Error("Failed to create request: %v", err)
+			c.breaker.release()
 			return nil, err
 		}
-		newReq.Header = req.Header
-		
-		if req.Body != nil {
-			// Read the original body
-			body, err := io.ReadAll(req.Body)
-			if err != nil {
-				c.logger.Error("Failed to read request body: %v", err)
-				return nil, err
+		if stats.Delay > 0 {
+			tflog.SubsystemDebug(ctx, LogSubsystem, "Rate limiter delayed request", fields, attempt, map[string]interface{}{
+				"wait_ms":       stats.Delay.Milliseconds(),
+				"rate":          stats.Rate,
+				"delayed_total": stats.Waits,
+				"wait_total_ms": stats.Waited.Milliseconds(),
+			})
+		}
+
+		// Clone the request for each retry, since a sent body cannot be re-read
+		newReq := req.Clone(req.Context())
+		if payload != nil {
+			newReq.Body = io.NopCloser(bytes.NewReader(payload))
+			newReq.ContentLength = int64(len(payload))
+			newReq.GetBody = func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(payload)), nil
 			}
-			newReq.Body = io.NopCloser(strings.NewReader(string(body)))
 		}
-		
-		c.logger.LogRequest(newReq)
+
+		tflog.SubsystemDebug(ctx, LogSubsystem, "Sending API request", fields, attempt)
+		if payload != nil {
+			tflog.SubsystemTrace(ctx, LogSubsystem, "API request body", fields, attempt, map[string]interface{}{"body": c.redactBody(payload)})
+		}
+
+		start := time.Now()
 		resp, err := c.httpClient.Do(newReq)
+		duration := map[string]interface{}{"duration_ms": time.Since(start).Milliseconds()}
+		if err != nil && req.Context().Err() != nil {
+			// Cancelled by the caller, which says nothing about the API
+			c.breaker.release()
+		} else {
+			c.recordOutcome(ctx, resp, err)
+		}
 		if err != nil {
-			c.logger.Error("Request failed: %v", err)
-			c.logger.LogRetry(attemptCount, err)
+			tflog.SubsystemWarn(ctx, LogSubsystem, "API request failed", fields, attempt, duration, map[string]interface{}{"error": err.Error()})
 			return nil, err
 		}
-		c.logger.LogResponse(resp)
-		
+		tflog.SubsystemDebug(ctx, LogSubsystem, "Received API response", fields, attempt, duration, map[string]interface{}{
+			"status":     resp.StatusCode,
+			"request_id": resp.Header.Get(RequestIDHeader),
+		})
+		c.limiter.observe(resp)
+
 		return resp, nil
 	})
 
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		if errors.Is(err, ErrServiceUnavailable) {
+			return attemptCount, err
+		}
+		if resp == nil {
+			tflog.SubsystemError(ctx, LogSubsystem, "Giving up on API request", fields, map[string]interface{}{"error": err.Error()})
+			return attemptCount, fmt.Errorf("failed to execute request: %w", err)
+		}
+		tflog.SubsystemError(ctx, LogSubsystem, "Giving up on API request", fields, map[string]interface{}{"error": err.Error()})
+		if errors.Is(err, context.DeadlineExceeded) {
+			// The deadline cut the retries short, which matters more than
+			// the status of the last attempt
+			discardBody(resp)
+			return attemptCount, fmt.Errorf("failed to execute request: %w", err)
+		}
+		// Retries are exhausted; report the last response below
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode >= 400 && !isRetryable(resp, nil) {
-		body, _ := io.ReadAll(resp.Body)
-		c.logger.Error("Request failed with status %d: %s", resp.StatusCode, string(body))
+	if resp.StatusCode >= 400 {
+		apiErr := newAPIError(resp)
+		tflog.SubsystemDebug(ctx, LogSubsystem, "API request returned an error", fields, map[string]interface{}{
+			"status":     apiErr.StatusCode,
+			"code":       apiErr.Code,
+			"request_id": apiErr.RequestID,
+			"error":      apiErr.Error(),
+		})
 		
 		if resp.StatusCode == http.StatusNotFound {
 			// Extract resource type from URL path
-			pathParts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+			pathParts := c.resourcePath(req)
 			resourceType := ""
 			id := ""
 			if len(pathParts) >= 2 {
 				resourceType = pathParts[0]
 				id = pathParts[1]
 			}
-			return &ResourceNotFoundError{
+			return attemptCount, &ResourceNotFoundError{
 				ResourceType: resourceType,
 				ID:          id,
 			}
 		}
 		
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return attemptCount, apiErr
 	}
 
 	if v != nil {
 		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
-			c.logger.Error("Failed to decode response: %v", err)
-			return fmt.Errorf("failed to decode response: %w", err)
+			tflog.SubsystemError(ctx, LogSubsystem, "Failed to decode API response", fields, map[string]interface{}{"error": err.Error()})
+			return attemptCount, fmt.Errorf("failed to decode response: %w", err)
+		}
+		if r, ok := v.(versioned); ok {
+			r.setETag(resp.Header.Get(ETagHeader))
 		}
-		c.logger.Debug("Successfully decoded response")
 	}
 
-	return nil
+	return attemptCount, nil
 }
\ No newline at end of file
@@ -2,9 +2,11 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -76,7 +78,7 @@ func TestClient_UpdateGroup(t *testing.T) {
 	defer srv.Close()
 
 	client := NewClient(srv.URL, "test-token")
-	group, err := client.UpdateGroup(context.Background(), "test-id", "updated-group", "updated description")
+	group, err := client.UpdateGroup(context.Background(), "test-id", "updated-group", "updated description", "")
 
 	assert.NoError(t, err)
 	assert.Equal(t, "test-id", group.ID)
@@ -95,7 +97,493 @@ func TestClient_DeleteGroup(t *testing.T) {
 	defer srv.Close()
 
 	client := NewClient(srv.URL, "test-token")
-	err := client.DeleteGroup(context.Background(), "test-id")
+	err := client.DeleteGroup(context.Background(), "test-id", "")
 
 	assert.NoError(t, err)
-}
\ No newline at end of file
+}
+
+func TestClient_TenantHeader(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "acme", r.Header.Get(TenantHeader))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test-token", WithTenantID("acme"))
+	assert.Equal(t, "acme", client.TenantID())
+
+	err := client.DeleteGroup(context.Background(), "test-id", "")
+	assert.NoError(t, err)
+}
+
+func TestClient_CreateRole(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/roles", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		var payload map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, "Cashier", payload["title"])
+		assert.Equal(t, []interface{}{"pos.receipt.read"}, payload["permissions"])
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{
+			"id": "role-id",
+			"title": "Cashier",
+			"description": "Point of sale cashier",
+			"permissions": ["pos.receipt.read"]
+		}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test-token")
+	role, err := client.CreateRole(context.Background(), "Cashier", "Point of sale cashier", []string{"pos.receipt.read"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "role-id", role.ID)
+	assert.Equal(t, "Cashier", role.Title)
+	assert.Equal(t, []string{"pos.receipt.read"}, role.Permissions)
+}
+
+func TestClient_GetRole(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/roles/role-id", r.URL.Path)
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test-token")
+	_, err := client.GetRole(context.Background(), "role-id")
+
+	assert.True(t, IsResourceNotFound(err))
+}
+
+func TestClient_RoleBindings(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/groups/group-id/roles":
+			w.Write([]byte(`{"items": [{"roleId": "role-id", "bindings": ["bu:001", "bu:002"]}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/groups/group-id/roles":
+			var binding RoleBinding
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&binding))
+			assert.Equal(t, "role-id", binding.RoleID)
+			assert.Equal(t, []string{"bu:001"}, binding.Bindings)
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(binding)
+		case r.Method == http.MethodDelete && r.URL.Path == "/groups/group-id/roles/role-id":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test-token")
+	ctx := context.Background()
+
+	bindings, err := client.ListRoleBindings(ctx, "group-id")
+	assert.NoError(t, err)
+	assert.Equal(t, []RoleBinding{{RoleID: "role-id", Bindings: []string{"bu:001", "bu:002"}}}, bindings)
+
+	binding, err := client.AddRoleBinding(ctx, "group-id", RoleBinding{RoleID: "role-id", Bindings: []string{"bu:001"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "role-id", binding.RoleID)
+
+	err = client.RemoveRoleBinding(ctx, "group-id", "role-id")
+	assert.NoError(t, err)
+}
+
+func TestClient_GroupMembers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/groups/group-id/members":
+			if r.URL.Query().Get("cursor") == "" {
+				w.Write([]byte(`{"items": [{"id": "user-1"}], "nextCursor": "page-2"}`))
+			} else {
+				assert.Equal(t, "page-2", r.URL.Query().Get("cursor"))
+				w.Write([]byte(`{"items": [{"id": "user-2"}]}`))
+			}
+		case r.Method == http.MethodPut && r.URL.Path == "/groups/group-id/members/user-3":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && r.URL.Path == "/groups/group-id/members/user-1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test-token")
+	ctx := context.Background()
+
+	members, err := client.ListGroupMembers(ctx, "group-id")
+	assert.NoError(t, err)
+	assert.Equal(t, []GroupMember{{ID: "user-1"}, {ID: "user-2"}}, members)
+
+	assert.NoError(t, client.AddGroupMember(ctx, "group-id", "user-3"))
+	assert.NoError(t, client.RemoveGroupMember(ctx, "group-id", "user-1"))
+}
+
+func TestClient_ListRoleBindings_Pagination(t *testing.T) {
+	pages := map[string]string{
+		"":       `{"items": [{"roleId": "role-1", "bindings": ["bu:001"]}], "nextCursor": "page-2"}`,
+		"page-2": `{"items": [{"roleId": "role-2", "bindings": ["bu:002"]}], "nextCursor": ""}`,
+	}
+
+	var cursors []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/groups/group-id/roles", r.URL.Path)
+
+		cursor := r.URL.Query().Get("cursor")
+		cursors = append(cursors, cursor)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(pages[cursor]))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test-token")
+	bindings, err := client.ListRoleBindings(context.Background(), "group-id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "page-2"}, cursors)
+	assert.Equal(t, []RoleBinding{
+		{RoleID: "role-1", Bindings: []string{"bu:001"}},
+		{RoleID: "role-2", Bindings: []string{"bu:002"}},
+	}, bindings)
+}
+
+func TestClient_ListGroupMembers_RepeatedCursor(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [{"id": "user-1"}], "nextCursor": "same"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test-token")
+	_, err := client.ListGroupMembers(context.Background(), "group-id")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "same page cursor")
+}
+
+func TestClient_FindGroupsByName(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/groups", r.URL.Path)
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "store staff", r.URL.Query().Get("name"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"items": [
+				{"id": "id-1", "name": "store staff", "description": "Store staff"},
+				{"id": "id-2", "name": "store staff-leads", "description": "Store staff leads"}
+			]
+		}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test-token")
+	groups, err := client.FindGroupsByName(context.Background(), "store staff")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Group{{ID: "id-1", Name: "store staff", Description: "Store staff"}}, groups)
+}
+
+func TestClient_ListGroups_Pagination(t *testing.T) {
+	pages := map[string]string{
+		"":       `{"items": [{"id": "id-1", "name": "alpha"}, {"id": "id-2", "name": "beta"}], "nextCursor": "page-2"}`,
+		"page-2": `{"items": [], "nextCursor": "page-3"}`,
+		"page-3": `{"items": [{"id": "id-3", "name": "gamma"}], "nextCursor": ""}`,
+	}
+
+	var cursors []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/groups", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("limit"))
+
+		cursor := r.URL.Query().Get("cursor")
+		cursors = append(cursors, cursor)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(pages[cursor]))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test-token")
+	groups, err := client.ListGroups(context.Background(), ListGroupsOptions{PageSize: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "page-2", "page-3"}, cursors)
+	assert.Len(t, groups, 3)
+	assert.Equal(t, "id-3", groups[2].ID)
+}
+
+func TestClient_ListGroups_RepeatedCursor(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [{"id": "id-1", "name": "alpha"}], "nextCursor": "same"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test-token")
+	_, err := client.ListGroups(context.Background(), ListGroupsOptions{})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "same page cursor")
+}
+
+// replayServer fails the first request with 503 and records every request body
+func replayServer(t *testing.T, bodies *[]map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		*bodies = append(*bodies, body)
+
+		if len(*bodies) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "test-id", "name": "test-group", "description": "test description"}`))
+	}))
+}
+
+func TestClient_CreateGroup_RetryReplaysBody(t *testing.T) {
+	var bodies []map[string]string
+	srv := replayServer(t, &bodies)
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test-token")
+	_, err := client.CreateGroup(context.Background(), "test-group", "test description")
+
+	assert.NoError(t, err)
+	assert.Len(t, bodies, 2)
+	assert.Equal(t, bodies[0], bodies[1])
+	assert.Equal(t, "test-group", bodies[1]["name"])
+	assert.Equal(t, "test description", bodies[1]["description"])
+}
+
+func TestClient_UpdateGroup_RetryReplaysBody(t *testing.T) {
+	var bodies []map[string]string
+	srv := replayServer(t, &bodies)
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test-token")
+	_, err := client.UpdateGroup(context.Background(), "test-id", "test-group", "test description", "")
+
+	assert.NoError(t, err)
+	assert.Len(t, bodies, 2)
+	assert.Equal(t, bodies[0], bodies[1])
+	assert.Equal(t, "test-group", bodies[1]["name"])
+	assert.Equal(t, "test description", bodies[1]["description"])
+}
+
+func TestClient_CreateGroup_IdempotencyKeyStableAcrossRetries(t *testing.T) {
+	var keys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": "test-id", "name": "test-group", "description": "test description"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test-token")
+	_, err := client.CreateGroup(context.Background(), "test-group", "test description")
+	assert.NoError(t, err)
+
+	// A second logical create gets a fresh key
+	_, err = client.CreateGroup(context.Background(), "test-group", "test description")
+	assert.NoError(t, err)
+
+	assert.Len(t, keys, 3)
+	assert.NotEmpty(t, keys[0])
+	assert.Equal(t, keys[0], keys[1])
+	assert.NotEqual(t, keys[1], keys[2])
+}
+
+func TestClient_CreateGroup_ConflictRecovery(t *testing.T) {
+	posts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			// The first attempt creates the group but its response is lost
+			posts++
+			if posts == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`group name already in use`))
+			return
+		}
+
+		assert.Equal(t, "/groups", r.URL.Path)
+		assert.Equal(t, "test-group", r.URL.Query().Get("name"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [
+			{"id": "other-id", "name": "test-group-2", "description": "test description"},
+			{"id": "test-id", "name": "test-group", "description": "test description"}
+		]}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test-token")
+	group, err := client.CreateGroup(context.Background(), "test-group", "test description")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "test-id", group.ID)
+}
+
+func TestClient_CreateGroup_ConflictWithDifferentGroup(t *testing.T) {
+	posts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			posts++
+			if posts == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [{"id": "other-id", "name": "test-group", "description": "someone else's group"}]}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test-token")
+	group, err := client.CreateGroup(context.Background(), "test-group", "test description")
+
+	assert.Nil(t, group)
+	assert.True(t, IsConflict(err))
+}
+
+func TestClient_CreateGroup_ConflictOnFirstAttempt(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		// A group with the same name and description was made by hand
+		w.WriteHeader(http.StatusConflict)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test-token")
+	group, err := client.CreateGroup(context.Background(), "test-group", "test description")
+
+	assert.Nil(t, group)
+	assert.True(t, IsConflict(err))
+}
+
+func TestClient_ContextDeadlineOverridesTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test-token")
+	client.timeouts.Delete = 10 * time.Millisecond
+
+	// Without a deadline on the context the client's own timeout applies
+	err := client.DeleteGroup(context.Background(), "test-id", "")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// A longer deadline, such as a resource timeout, takes precedence
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, client.DeleteGroup(ctx, "test-id", ""))
+}
+
+func TestClient_GroupETag(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": "test-id", "name": "test-group", "description": "test description"}`))
+		case http.MethodPut, http.MethodDelete:
+			if r.Header.Get("If-Match") != `"v1"` {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			w.Header().Set("ETag", `"v2"`)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": "test-id", "name": "updated-group", "description": "test description"}`))
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test-token")
+
+	group, err := client.GetGroup(context.Background(), "test-id")
+	assert.NoError(t, err)
+	assert.Equal(t, `"v1"`, group.ETag)
+
+	group, err = client.UpdateGroup(context.Background(), "test-id", "updated-group", "test description", `"v1"`)
+	assert.NoError(t, err)
+	assert.Equal(t, `"v2"`, group.ETag)
+
+	// A stale version is rejected
+	_, err = client.UpdateGroup(context.Background(), "test-id", "updated-group", "test description", `"v0"`)
+	assert.True(t, IsPreconditionFailed(err))
+	assert.True(t, IsPreconditionFailed(client.DeleteGroup(context.Background(), "test-id", `"v0"`)))
+}
+
+func TestClient_PatchGroup(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/groups/test-id", r.URL.Path)
+		assert.Equal(t, MergePatchContentType, r.Header.Get("Content-Type"))
+		assert.Equal(t, `"v1"`, r.Header.Get("If-Match"))
+
+		// Only the changed attribute is sent
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{"description": ""}, body)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "test-id", "name": "test-group", "description": ""}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test-token")
+	description := ""
+	group, err := client.PatchGroup(context.Background(), "test-id", GroupPatch{Description: &description}, `"v1"`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "test-group", group.Name)
+}
+
+func TestClient_PatchGroup_NotSupported(t *testing.T) {
+	for _, status := range []int{http.StatusMethodNotAllowed, http.StatusNotImplemented} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+
+		client := NewClient(srv.URL, "test-token")
+		name := "test-group"
+		_, err := client.PatchGroup(context.Background(), "test-id", GroupPatch{Name: &name}, "")
+
+		assert.True(t, IsNotSupported(err), "status %d", status)
+		srv.Close()
+	}
+}
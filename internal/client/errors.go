@@ -1,6 +1,16 @@
 package client
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// RequestIDHeader is the response header carrying the API's request ID
+const RequestIDHeader = "X-Request-ID"
 
 // ResourceNotFoundError represents an error when a resource is not found
 type ResourceNotFoundError struct {
@@ -14,6 +24,175 @@ func (e *ResourceNotFoundError) Error() string {
 
 // IsResourceNotFound checks if the given error is a ResourceNotFoundError
 func IsResourceNotFound(err error) bool {
-	_, ok := err.(*ResourceNotFoundError)
-	return ok
-}
\ No newline at end of file
+	var notFound *ResourceNotFoundError
+	return errors.As(err, &notFound)
+}
+
+// FieldViolation describes a request field the API rejected
+type FieldViolation struct {
+	Field   string
+	Message string
+}
+
+// APIError represents an error response from the IAM API
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Violations []FieldViolation
+	RequestID  string
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "API request failed with status %d", e.StatusCode)
+	if e.Code != "" {
+		fmt.Fprintf(&b, " (%s)", e.Code)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	for _, v := range e.Violations {
+		fmt.Fprintf(&b, "; %s: %s", v.Field, v.Message)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request ID: %s)", e.RequestID)
+	}
+	return b.String()
+}
+
+// AsAPIError returns the APIError in err's chain, if any
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// hasStatus checks if err is an APIError with one of the given status codes
+func hasStatus(err error, codes ...int) bool {
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		return false
+	}
+	for _, code := range codes {
+		if apiErr.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// IsConflict checks if the API rejected a request as conflicting with an existing resource
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsForbidden checks if the API rejected a request for lack of permissions
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized, http.StatusForbidden)
+}
+
+// IsValidation checks if the API rejected a request as invalid
+func IsValidation(err error) bool {
+	return hasStatus(err, http.StatusBadRequest, http.StatusUnprocessableEntity)
+}
+
+// IsPreconditionFailed checks if the API rejected a conditional request
+// because the resource changed since the version it was made against
+func IsPreconditionFailed(err error) bool {
+	return hasStatus(err, http.StatusPreconditionFailed)
+}
+
+// IsNotSupported checks if the API does not support the request method
+func IsNotSupported(err error) bool {
+	return hasStatus(err, http.StatusMethodNotAllowed, http.StatusNotImplemented)
+}
+
+// IsRateLimited checks if the API rejected a request because of rate limiting
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// problemBody is an RFC 7807 problem+json body, as returned by the API gateway
+type problemBody struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Detail   string `json:"detail"`
+	Instance string `json:"instance"`
+	Errors   []struct {
+		Name   string `json:"name"`
+		Field  string `json:"field"`
+		Reason string `json:"reason"`
+	} `json:"errors"`
+	InvalidParams []struct {
+		Name   string `json:"name"`
+		Reason string `json:"reason"`
+	} `json:"invalid-params"`
+}
+
+// iamErrorBody is the error body returned by the IAM service itself
+type iamErrorBody struct {
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	RequestID  string `json:"requestId"`
+	Violations []struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	} `json:"violations"`
+}
+
+// newAPIError builds an APIError from a failed response, reading its body
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get(RequestIDHeader),
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	body = []byte(strings.TrimSpace(string(body)))
+	if len(body) == 0 {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+		return apiErr
+	}
+
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/problem+json") {
+		var problem problemBody
+		if err := json.Unmarshal(body, &problem); err == nil {
+			apiErr.Code = problem.Type
+			apiErr.Message = problem.Detail
+			if apiErr.Message == "" {
+				apiErr.Message = problem.Title
+			}
+			for _, e := range problem.Errors {
+				field := e.Field
+				if field == "" {
+					field = e.Name
+				}
+				apiErr.Violations = append(apiErr.Violations, FieldViolation{Field: field, Message: e.Reason})
+			}
+			for _, p := range problem.InvalidParams {
+				apiErr.Violations = append(apiErr.Violations, FieldViolation{Field: p.Name, Message: p.Reason})
+			}
+			return apiErr
+		}
+	}
+
+	var iam iamErrorBody
+	if err := json.Unmarshal(body, &iam); err == nil && (iam.Code != "" || iam.Message != "") {
+		apiErr.Code = iam.Code
+		apiErr.Message = iam.Message
+		if apiErr.RequestID == "" {
+			apiErr.RequestID = iam.RequestID
+		}
+		for _, v := range iam.Violations {
+			apiErr.Violations = append(apiErr.Violations, FieldViolation{Field: v.Field, Message: v.Message})
+		}
+		return apiErr
+	}
+
+	// Not a known error format; keep the raw body as the message
+	apiErr.Message = string(body)
+	return apiErr
+}
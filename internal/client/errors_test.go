@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIError_ProblemJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.Header().Set(RequestIDHeader, "req-123")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{
+			"type": "validation-error",
+			"title": "Bad Request",
+			"detail": "The request is invalid",
+			"invalid-params": [{"name": "name", "reason": "must be unique"}]
+		}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test-token")
+	_, err := client.CreateGroup(context.Background(), "test-group", "test description")
+
+	apiErr, ok := AsAPIError(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(t, "validation-error", apiErr.Code)
+	assert.Equal(t, "The request is invalid", apiErr.Message)
+	assert.Equal(t, []FieldViolation{{Field: "name", Message: "must be unique"}}, apiErr.Violations)
+	assert.Equal(t, "req-123", apiErr.RequestID)
+	assert.True(t, IsValidation(err))
+}
+
+func TestAPIError_IAMBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{
+			"code": "INVALID_ARGUMENT",
+			"message": "Group is invalid",
+			"requestId": "req-456",
+			"violations": [{"field": "description", "message": "too long"}]
+		}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test-token")
+	_, err := client.UpdateGroup(context.Background(), "test-id", "test-group", "test description", "")
+
+	apiErr, ok := AsAPIError(err)
+	assert.True(t, ok)
+	assert.Equal(t, "INVALID_ARGUMENT", apiErr.Code)
+	assert.Equal(t, "Group is invalid", apiErr.Message)
+	assert.Equal(t, []FieldViolation{{Field: "description", Message: "too long"}}, apiErr.Violations)
+	assert.Equal(t, "req-456", apiErr.RequestID)
+	assert.EqualError(t, err, "API request failed with status 422 (INVALID_ARGUMENT): Group is invalid; description: too long (request ID: req-456)")
+}
+
+func TestAPIError_PlainBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("access denied"))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test-token")
+	err := client.DeleteGroup(context.Background(), "test-id", "")
+
+	assert.True(t, IsForbidden(err))
+	assert.EqualError(t, err, "API request failed with status 403: access denied")
+}
+
+func TestAPIError_RateLimitedAfterRetries(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"code": "RATE_LIMITED", "message": "slow down"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test-token")
+	client.retry = RetryConfig{MaxRetries: 1, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, Multiplier: 1}
+	_, err := client.GetGroup(context.Background(), "test-id")
+
+	assert.True(t, IsRateLimited(err))
+	apiErr, _ := AsAPIError(err)
+	assert.Equal(t, "slow down", apiErr.Message)
+}
+
+func TestErrorHelpers_Wrapped(t *testing.T) {
+	tests := []struct {
+		err   error
+		check func(error) bool
+	}{
+		{&APIError{StatusCode: http.StatusConflict}, IsConflict},
+		{&APIError{StatusCode: http.StatusForbidden}, IsForbidden},
+		{&APIError{StatusCode: http.StatusUnauthorized}, IsForbidden},
+		{&APIError{StatusCode: http.StatusBadRequest}, IsValidation},
+		{&APIError{StatusCode: http.StatusTooManyRequests}, IsRateLimited},
+		{&ResourceNotFoundError{ResourceType: "groups", ID: "test-id"}, IsResourceNotFound},
+	}
+
+	for _, tt := range tests {
+		wrapped := fmt.Errorf("context: %w", tt.err)
+		assert.True(t, tt.check(tt.err), tt.err.Error())
+		assert.True(t, tt.check(wrapped), wrapped.Error())
+	}
+
+	assert.False(t, IsConflict(&APIError{StatusCode: http.StatusBadRequest}))
+	assert.False(t, IsConflict(fmt.Errorf("plain error")))
+}
+
+func TestClient_NotFoundBelowBasePath(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/groups/test-id", r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL+"/api/v1", "test-token")
+	_, err := client.GetGroup(context.Background(), "test-id")
+
+	assert.True(t, IsResourceNotFound(err))
+	assert.EqualError(t, err, "groups with ID test-id not found")
+}
@@ -0,0 +1,29 @@
+package client
+
+import "net/http"
+
+// Headers used for optimistic concurrency. The API returns the version of a
+// resource in the ETag header, and rejects an update or delete carrying a
+// stale version in If-Match with 412 Precondition Failed.
+const (
+	ETagHeader    = "ETag"
+	IfMatchHeader = "If-Match"
+)
+
+// versioned is implemented by resources the API versions with an ETag
+type versioned interface {
+	setETag(etag string)
+}
+
+func (g *Group) setETag(etag string) { g.ETag = etag }
+
+func (r *Role) setETag(etag string) { r.ETag = etag }
+
+// setIfMatch makes req conditional on the resource still being at version
+// etag. Without an etag, e.g. for state written by older provider versions,
+// the request is sent unconditionally.
+func setIfMatch(req *http.Request, etag string) {
+	if etag != "" {
+		req.Header.Set(IfMatchHeader, etag)
+	}
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// ListGroupsOptions filters and pages a group listing
+type ListGroupsOptions struct {
+	// Name restricts the listing to groups matching this name. The API may
+	// match loosely, so callers needing exact matches must filter themselves.
+	Name string
+	// PageSize is the number of groups requested per page. Zero uses the API default.
+	PageSize int
+}
+
+// GroupIterator walks a group listing page by page, following the cursor
+// returned by the API. Use it like:
+//
+//	it := c.Groups(ListGroupsOptions{})
+//	for it.Next(ctx) {
+//		group := it.Group()
+//	}
+//	if err := it.Err(); err != nil { ... }
+//
+// The iterator applies no timeout of its own; the caller's context bounds
+// every page request.
+type GroupIterator struct {
+	client *Client
+	opts   ListGroupsOptions
+
+	page    []Group
+	cursor  string
+	done    bool
+	current Group
+	err     error
+}
+
+// Groups returns an iterator over all IAM groups matching opts
+func (c *Client) Groups(opts ListGroupsOptions) *GroupIterator {
+	return &GroupIterator{
+		client: c,
+		opts:   opts,
+	}
+}
+
+// Next advances to the next group, fetching the next page when needed.
+// It returns false when the listing is exhausted or an error occurred.
+func (it *GroupIterator) Next(ctx context.Context) bool {
+	for len(it.page) == 0 {
+		if it.err != nil || it.done {
+			return false
+		}
+		it.fetch(ctx)
+	}
+
+	it.current = it.page[0]
+	it.page = it.page[1:]
+	return true
+}
+
+// Group returns the group the iterator is positioned at
+func (it *GroupIterator) Group() Group {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *GroupIterator) Err() error {
+	return it.err
+}
+
+// fetch requests the next page of groups
+func (it *GroupIterator) fetch(ctx context.Context) {
+	query := url.Values{}
+	if it.opts.Name != "" {
+		query.Set("name", it.opts.Name)
+	}
+	if it.opts.PageSize > 0 {
+		query.Set("limit", strconv.Itoa(it.opts.PageSize))
+	}
+
+	page, err := fetchPage[Group](ctx, it.client, "/groups", query, it.cursor)
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	it.page = page.Items
+	it.cursor = page.NextCursor
+	it.done = page.NextCursor == ""
+}
+
+// ListGroups returns all IAM groups matching opts, following pagination
+func (c *Client) ListGroups(ctx context.Context, opts ListGroupsOptions) ([]Group, error) {
+	var result []Group
+	err := withTimeout(ctx, c.timeouts.List, func(ctx context.Context) error {
+		it := c.Groups(opts)
+		for it.Next(ctx) {
+			result = append(result, it.Group())
+		}
+		return it.Err()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
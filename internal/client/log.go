@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// LogSubsystem is the tflog subsystem the client logs to. Its level follows
+// TF_LOG_PROVIDER, and can be set separately with
+// TF_LOG_PROVIDER_HIIRETAIL_CLIENT.
+const LogSubsystem = "client"
+
+// logLevelEnvVar is the prefix of the environment variable setting the level
+// of LogSubsystem
+const logLevelEnvVar = "TF_LOG_PROVIDER_HIIRETAIL"
+
+// logMask replaces masked values in log output, as tflog does
+const logMask = "***"
+
+// defaultSensitiveLogFields are the field and JSON body keys whose values are
+// never logged
+var defaultSensitiveLogFields = []string{
+	"authorization",
+	"access_token",
+	"refresh_token",
+	"client_secret",
+	"password",
+	"token",
+}
+
+// bearerPattern matches bearer credentials anywhere in a log message or field
+var bearerPattern = regexp.MustCompile(`(?i)bearer\s+[^\s"',]+`)
+
+// WithSensitiveLogFields masks the values of the given keys in log fields
+// and logged request bodies, in addition to tokens and client secrets
+func WithSensitiveLogFields(fields ...string) Option {
+	return func(c *Client) {
+		c.sensitiveLogFields = append(c.sensitiveLogFields, fields...)
+	}
+}
+
+// WithSensitiveLogValues masks the given values, such as a client secret,
+// wherever they would appear in log output
+func WithSensitiveLogValues(values ...string) Option {
+	return func(c *Client) {
+		for _, value := range values {
+			if value != "" {
+				c.sensitiveLogValues = append(c.sensitiveLogValues, value)
+			}
+		}
+	}
+}
+
+// logContext returns ctx with the client's log subsystem, masking every
+// sensitive value the client knows of. The token in the Authorization header
+// of req, if any, is masked as well.
+func (c *Client) logContext(ctx context.Context, req *http.Request) context.Context {
+	ctx = tflog.NewSubsystem(ctx, LogSubsystem, tflog.WithLevelFromEnv(logLevelEnvVar, LogSubsystem))
+
+	fields := c.logFieldKeys()
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, LogSubsystem, fields...)
+	ctx = tflog.SubsystemMaskAllFieldValuesRegexes(ctx, LogSubsystem, bearerPattern)
+	ctx = tflog.SubsystemMaskMessageRegexes(ctx, LogSubsystem, bearerPattern)
+
+	values := c.sensitiveLogValues
+	if req != nil {
+		if token := strings.TrimSpace(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer")); token != "" {
+			values = append(values[:len(values):len(values)], token)
+		}
+	}
+	if len(values) > 0 {
+		ctx = tflog.SubsystemMaskLogStrings(ctx, LogSubsystem, values...)
+	}
+
+	return ctx
+}
+
+// logFieldKeys returns the sensitive keys, in the spellings they are likely
+// to appear in
+func (c *Client) logFieldKeys() []string {
+	var keys []string
+	for _, key := range append(defaultSensitiveLogFields, c.sensitiveLogFields...) {
+		keys = append(keys, key, strings.ToLower(key), strings.ToUpper(key), http.CanonicalHeaderKey(key))
+	}
+	return keys
+}
+
+// isSensitiveLogField reports whether the value of key must not be logged
+func (c *Client) isSensitiveLogField(key string) bool {
+	for _, field := range defaultSensitiveLogFields {
+		if strings.EqualFold(key, field) {
+			return true
+		}
+	}
+	for _, field := range c.sensitiveLogFields {
+		if strings.EqualFold(key, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactBody returns a JSON body for logging, with the values of sensitive
+// keys masked at any depth. Bodies that are not JSON are not logged.
+func (c *Client) redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return "(non-JSON body omitted)"
+	}
+
+	redacted, err := json.Marshal(c.redactValue(value))
+	if err != nil {
+		return "(body omitted)"
+	}
+	return string(redacted)
+}
+
+// redactValue masks the values of sensitive keys in a decoded JSON value
+func (c *Client) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if c.isSensitiveLogField(key) {
+				v[key] = logMask
+				continue
+			}
+			v[key] = c.redactValue(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = c.redactValue(item)
+		}
+	}
+	return value
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_LogsStructuredFields(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_HIIRETAIL_CLIENT", "TRACE")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": "test-id", "name": "test-group"}`))
+	}))
+	defer srv.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client := NewClient(srv.URL, "test-token")
+	_, err := client.GetGroup(ctx, "test-id")
+	require.NoError(t, err)
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	require.NoError(t, err)
+
+	var response map[string]interface{}
+	for _, entry := range entries {
+		if entry["@message"] == "Received API response" {
+			response = entry
+		}
+	}
+	require.NotNil(t, response, "no response logged")
+	assert.Contains(t, response["@module"], LogSubsystem)
+	assert.Equal(t, http.MethodGet, response["method"])
+	assert.Equal(t, "/groups/test-id", response["path"])
+	assert.Equal(t, float64(http.StatusOK), response["status"])
+	assert.Equal(t, float64(1), response["attempt"])
+	assert.Contains(t, response, "duration_ms")
+	assert.Equal(t, "req-123", response["request_id"])
+}
+
+func TestClient_LogsMaskSecrets(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_HIIRETAIL_CLIENT", "TRACE")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Echo the token and secret back, as a misbehaving API might
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message": "invalid token super-secret-token for secret very-secret"}`))
+	}))
+	defer srv.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client := NewClient(srv.URL, "super-secret-token",
+		WithSensitiveLogValues("very-secret"),
+		WithSensitiveLogFields("description"),
+	)
+	_, err := client.CreateGroup(ctx, "test-group", "private description")
+	require.Error(t, err)
+
+	logged := output.String()
+	assert.Contains(t, logged, "Sending API request")
+	assert.Contains(t, logged, "test-group")
+	assert.NotContains(t, logged, "super-secret-token")
+	assert.NotContains(t, logged, "very-secret")
+	assert.NotContains(t, logged, "private description")
+}
+
+func TestClient_RedactBody(t *testing.T) {
+	client := NewClient("http://localhost", "", WithSensitiveLogFields("Description"))
+
+	redacted := client.redactBody([]byte(`{
+		"name": "test-group",
+		"description": "private",
+		"credentials": [{"client_secret": "secret", "access_token": "token"}]
+	}`))
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(redacted), &body))
+	assert.Equal(t, "test-group", body["name"])
+	assert.Equal(t, logMask, body["description"])
+	credentials := body["credentials"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, logMask, credentials["client_secret"])
+	assert.Equal(t, logMask, credentials["access_token"])
+
+	assert.Equal(t, "(non-JSON body omitted)", client.redactBody([]byte("client_secret=secret")))
+	assert.Equal(t, "", client.redactBody(nil))
+}
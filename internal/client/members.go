@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// GroupMember represents a user that is a member of an IAM group.
+//
+// Fields:
+//   - ID: Identifier of the user
+type GroupMember struct {
+	ID string `json:"id"`
+}
+
+// ListGroupMembers returns all members of a group, following pagination
+func (c *Client) ListGroupMembers(ctx context.Context, groupID string) ([]GroupMember, error) {
+	var result []GroupMember
+	err := withTimeout(ctx, c.timeouts.List, func(ctx context.Context) error {
+		members, err := listAll[GroupMember](ctx, c, fmt.Sprintf("/groups/%s/members", groupID))
+		if err != nil {
+			return err
+		}
+
+		result = members
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// AddGroupMember adds a user to a group. Adding an existing member is a no-op.
+func (c *Client) AddGroupMember(ctx context.Context, groupID string, userID string) error {
+	return withTimeout(ctx, c.timeouts.Create, func(ctx context.Context) error {
+		req, err := c.newRequest(ctx, http.MethodPut, fmt.Sprintf("/groups/%s/members/%s", groupID, userID), nil)
+		if err != nil {
+			return err
+		}
+
+		return c.do(req, nil)
+	})
+}
+
+// RemoveGroupMember removes a user from a group
+func (c *Client) RemoveGroupMember(ctx context.Context, groupID string, userID string) error {
+	return withTimeout(ctx, c.timeouts.Delete, func(ctx context.Context) error {
+		req, err := c.newRequest(ctx, http.MethodDelete, fmt.Sprintf("/groups/%s/members/%s", groupID, userID), nil)
+		if err != nil {
+			return err
+		}
+
+		return c.do(req, nil)
+	})
+}
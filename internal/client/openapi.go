@@ -0,0 +1,239 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SupportedAPIMajorVersion is the major version of the IAM API this client speaks
+const SupportedAPIMajorVersion = 1
+
+// OpenAPIDocument holds the parts of the IAM API's OpenAPI document used for
+// endpoint discovery
+type OpenAPIDocument struct {
+	Info    OpenAPIInfo     `json:"info"`
+	Servers []OpenAPIServer `json:"servers"`
+}
+
+// OpenAPIInfo is the info object of an OpenAPI document
+type OpenAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// OpenAPIServer is an entry of the servers list of an OpenAPI document
+type OpenAPIServer struct {
+	URL         string `json:"url"`
+	Description string `json:"description"`
+}
+
+// MajorVersion returns the major version of info.version, accepting forms
+// like "1", "v1" and "1.4.2"
+func (d *OpenAPIDocument) MajorVersion() (int, error) {
+	version := strings.TrimPrefix(strings.TrimSpace(d.Info.Version), "v")
+	major, _, _ := strings.Cut(version, ".")
+
+	n, err := strconv.Atoi(major)
+	if err != nil {
+		return 0, fmt.Errorf("invalid API version %q", d.Info.Version)
+	}
+
+	return n, nil
+}
+
+// CheckVersion returns an error if the document describes an API version
+// this client does not support
+func (d *OpenAPIDocument) CheckVersion() error {
+	major, err := d.MajorVersion()
+	if err != nil {
+		return err
+	}
+
+	if major != SupportedAPIMajorVersion {
+		return fmt.Errorf("the API reports version %s, but this provider supports major version %d only", d.Info.Version, SupportedAPIMajorVersion)
+	}
+
+	return nil
+}
+
+// ServerURL returns the first usable server URL of the document. Relative
+// server URLs are resolved against the URL the document was fetched from.
+// Servers using URL template variables are skipped.
+func (d *OpenAPIDocument) ServerURL(schemaURL string) (string, error) {
+	base, err := url.Parse(schemaURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid schema URL %q: %w", schemaURL, err)
+	}
+
+	for _, server := range d.Servers {
+		if server.URL == "" || strings.Contains(server.URL, "{") {
+			continue
+		}
+
+		ref, err := url.Parse(server.URL)
+		if err != nil {
+			continue
+		}
+
+		return strings.TrimSuffix(base.ResolveReference(ref).String(), "/"), nil
+	}
+
+	return "", fmt.Errorf("the OpenAPI document at %s lists no usable servers", schemaURL)
+}
+
+// cachedSchema is the on-disk form of a fetched OpenAPI document
+type cachedSchema struct {
+	ETag     string          `json:"etag"`
+	Document json.RawMessage `json:"document"`
+}
+
+// SchemaFetcher downloads OpenAPI documents, caching them on disk so that
+// unchanged documents are revalidated with If-None-Match and cached copies
+// are used when the schema host cannot be reached.
+type SchemaFetcher struct {
+	httpClient *http.Client
+	cacheDir   string
+}
+
+// NewSchemaFetcher creates a SchemaFetcher caching documents in cacheDir. An
+// empty cacheDir disables the cache. If httpClient is nil, a default client
+// is used.
+func NewSchemaFetcher(cacheDir string, httpClient *http.Client) *SchemaFetcher {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	return &SchemaFetcher{
+		httpClient: httpClient,
+		cacheDir:   cacheDir,
+	}
+}
+
+// Fetch returns the OpenAPI document published at schemaURL
+func (f *SchemaFetcher) Fetch(ctx context.Context, schemaURL string) (*OpenAPIDocument, error) {
+	cached := f.readCache(schemaURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, schemaURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create schema request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if cached != nil && cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		// Work offline from the cached copy
+		if cached != nil {
+			return decodeSchema(cached.Document)
+		}
+		return nil, fmt.Errorf("failed to fetch OpenAPI schema: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		return decodeSchema(cached.Document)
+	case resp.StatusCode >= 500 && cached != nil:
+		return decodeSchema(cached.Document)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("failed to fetch OpenAPI schema: status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI schema: %w", err)
+	}
+
+	doc, err := decodeSchema(body)
+	if err != nil {
+		return nil, err
+	}
+
+	f.writeCache(schemaURL, &cachedSchema{
+		ETag:     resp.Header.Get("ETag"),
+		Document: body,
+	})
+
+	return doc, nil
+}
+
+// decodeSchema parses an OpenAPI document
+func decodeSchema(data []byte) (*OpenAPIDocument, error) {
+	var doc OpenAPIDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode OpenAPI schema: %w", err)
+	}
+	return &doc, nil
+}
+
+// cachePath returns the cache file of the document at schemaURL
+func (f *SchemaFetcher) cachePath(schemaURL string) string {
+	sum := sha256.Sum256([]byte(schemaURL))
+	return filepath.Join(f.cacheDir, "openapi-"+hex.EncodeToString(sum[:8])+".json")
+}
+
+// readCache returns the cached document for schemaURL, or nil if there is none
+func (f *SchemaFetcher) readCache(schemaURL string) *cachedSchema {
+	if f.cacheDir == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(f.cachePath(schemaURL))
+	if err != nil {
+		return nil
+	}
+
+	var cached cachedSchema
+	if err := json.Unmarshal(data, &cached); err != nil || len(cached.Document) == 0 {
+		return nil
+	}
+
+	return &cached
+}
+
+// writeCache stores a fetched document. Failures are ignored, since the cache
+// only speeds up later runs.
+func (f *SchemaFetcher) writeCache(schemaURL string, cached *cachedSchema) {
+	if f.cacheDir == "" {
+		return
+	}
+
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(f.cacheDir, 0o755); err != nil {
+		return
+	}
+
+	// Write atomically so concurrent runs never read a partial file
+	tmp, err := os.CreateTemp(f.cacheDir, "openapi-*.tmp")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		return
+	}
+
+	os.Rename(tmp.Name(), f.cachePath(schemaURL))
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSchema = `{
+	"openapi": "3.0.3",
+	"info": {"title": "IAM API", "version": "1.4.0"},
+	"servers": [{"url": "https://iam-api.retailsvc.com/api/v1/"}]
+}`
+
+func TestSchemaFetcher_ETagCache(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(testSchema))
+	}))
+	defer srv.Close()
+
+	fetcher := NewSchemaFetcher(t.TempDir(), nil)
+
+	doc, err := fetcher.Fetch(context.Background(), srv.URL+"/openapi.json")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.4.0", doc.Info.Version)
+
+	// The second fetch revalidates and is served from the cache
+	doc, err = fetcher.Fetch(context.Background(), srv.URL+"/openapi.json")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.4.0", doc.Info.Version)
+	assert.Equal(t, 2, requests)
+}
+
+func TestSchemaFetcher_Offline(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(testSchema))
+	}))
+
+	cacheDir := t.TempDir()
+	schemaURL := srv.URL + "/openapi.json"
+
+	_, err := NewSchemaFetcher(cacheDir, nil).Fetch(context.Background(), schemaURL)
+	assert.NoError(t, err)
+
+	srv.Close()
+
+	// A new fetcher sharing the cache works without the server
+	doc, err := NewSchemaFetcher(cacheDir, nil).Fetch(context.Background(), schemaURL)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.4.0", doc.Info.Version)
+
+	// Without a cache the failure is reported
+	_, err = NewSchemaFetcher("", nil).Fetch(context.Background(), schemaURL)
+	assert.Error(t, err)
+}
+
+func TestOpenAPIDocument_CheckVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		wantErr bool
+	}{
+		{"1", false},
+		{"v1", false},
+		{"1.4.2", false},
+		{"2.0.0", true},
+		{"0.9", true},
+		{"latest", true},
+	}
+
+	for _, tt := range tests {
+		doc := &OpenAPIDocument{Info: OpenAPIInfo{Version: tt.version}}
+		if tt.wantErr {
+			assert.Error(t, doc.CheckVersion(), tt.version)
+		} else {
+			assert.NoError(t, doc.CheckVersion(), tt.version)
+		}
+	}
+}
+
+func TestOpenAPIDocument_ServerURL(t *testing.T) {
+	doc := &OpenAPIDocument{Servers: []OpenAPIServer{
+		{URL: "https://{region}.retailsvc.com"},
+		{URL: "/api/v1"},
+	}}
+
+	got, err := doc.ServerURL("https://iam-api.retailsvc.com/schemas/v1/openapi.json")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://iam-api.retailsvc.com/api/v1", got)
+
+	_, err = (&OpenAPIDocument{}).ServerURL("https://iam-api.retailsvc.com/schemas/v1/openapi.json")
+	assert.Error(t, err)
+}
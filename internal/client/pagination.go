@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// listPage is one page of a cursor-paginated listing
+type listPage[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"nextCursor"`
+}
+
+// fetchPage requests the page of the listing at path that starts at cursor,
+// or the first page if cursor is empty. It fails if the API hands back the
+// cursor it was given, which would otherwise loop forever.
+func fetchPage[T any](ctx context.Context, c *Client, path string, query url.Values, cursor string) (listPage[T], error) {
+	values := url.Values{}
+	for key, value := range query {
+		values[key] = value
+	}
+	if cursor != "" {
+		values.Set("cursor", cursor)
+	}
+	if len(values) > 0 {
+		path += "?" + values.Encode()
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return listPage[T]{}, err
+	}
+
+	var page listPage[T]
+	if err := c.do(req, &page); err != nil {
+		return listPage[T]{}, err
+	}
+
+	if page.NextCursor != "" && page.NextCursor == cursor {
+		return listPage[T]{}, fmt.Errorf("listing of %s returned the same page cursor %q twice", req.URL.Path, page.NextCursor)
+	}
+
+	return page, nil
+}
+
+// listAll returns every item of the listing at path, following the cursor
+// from page to page
+func listAll[T any](ctx context.Context, c *Client, path string) ([]T, error) {
+	var items []T
+	cursor := ""
+	for {
+		page, err := fetchPage[T](ctx, c, path, nil, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, page.Items...)
+		if page.NextCursor == "" {
+			return items, nil
+		}
+		cursor = page.NextCursor
+	}
+}
@@ -0,0 +1,208 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rate limit headers sent by the IAM API. Remaining is the number of requests
+// left in the current window and Reset the time until the window resets,
+// either in seconds or as a Unix timestamp.
+const (
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+	RateLimitResetHeader     = "X-RateLimit-Reset"
+)
+
+// rateLimiter is a token bucket shared by every request of a client, so the
+// resources Terraform manages in parallel are throttled together. The bucket
+// holds up to one second's worth of tokens, so short bursts are allowed while
+// the sustained rate stays at the limit.
+//
+// The rate is the configured limit, lowered while the API reports that its
+// own window is running out: the remaining requests are then spread evenly
+// until the window resets. An exhausted window, or a 429 with Retry-After,
+// pauses all requests until the API accepts them again.
+type rateLimiter struct {
+	mu  sync.Mutex
+	clk clock
+
+	// limit is the configured maximum rate, zero for none
+	limit  float64
+	tokens float64
+	last   time.Time
+
+	// serverRate is the rate derived from the API's headers, valid until serverUntil
+	serverRate  float64
+	serverUntil time.Time
+	pausedUntil time.Time
+
+	// waits and waited count the delayed requests and their total delay
+	waits  int
+	waited time.Duration
+}
+
+// rateLimiterStats describes a wait imposed by the limiter
+type rateLimiterStats struct {
+	// Delay is how long the request waited
+	Delay time.Duration
+	// Rate is the rate in effect, zero for unlimited
+	Rate float64
+	// Waits and Waited are the totals across all requests of the client
+	Waits  int
+	Waited time.Duration
+}
+
+// newRateLimiter returns a limiter allowing requestsPerSecond requests per
+// second, or any number of requests until the API asks for less if zero
+func newRateLimiter(requestsPerSecond float64, clk clock) *rateLimiter {
+	return &rateLimiter{
+		clk:    clk,
+		limit:  requestsPerSecond,
+		tokens: math.Inf(1),
+		last:   clk.Now(),
+	}
+}
+
+// rate returns the rate in effect at now, zero for unlimited
+func (l *rateLimiter) rate(now time.Time) float64 {
+	rate := l.limit
+	if now.Before(l.serverUntil) && (rate == 0 || l.serverRate < rate) {
+		rate = l.serverRate
+	}
+	return rate
+}
+
+// reserve takes a token from the bucket and returns how long the caller must
+// wait before the token is available
+func (l *rateLimiter) reserve() (time.Duration, float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clk.Now()
+	rate := l.rate(now)
+
+	var delay time.Duration
+	if rate == 0 {
+		l.tokens = math.Inf(1)
+	} else {
+		burst := math.Max(1, math.Floor(rate))
+		if elapsed := now.Sub(l.last); elapsed > 0 {
+			l.tokens += elapsed.Seconds() * rate
+		}
+		l.tokens = math.Min(burst, l.tokens) - 1
+		if l.tokens < 0 {
+			delay = time.Duration(-l.tokens / rate * float64(time.Second))
+		}
+	}
+	l.last = now
+
+	if pause := l.pausedUntil.Sub(now); pause > delay {
+		delay = pause
+	}
+
+	return delay, rate
+}
+
+// cancel returns a token that was reserved but not used
+func (l *rateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens++
+}
+
+// wait blocks until a request may be sent. It fails without waiting if the
+// wait would run past the context deadline. Waits are recorded in the
+// returned stats.
+func (l *rateLimiter) wait(ctx context.Context) (rateLimiterStats, error) {
+	delay, rate := l.reserve()
+	if delay == 0 {
+		return rateLimiterStats{Rate: rate}, nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && l.clk.Now().Add(delay).After(deadline) {
+		l.cancel()
+		return rateLimiterStats{}, fmt.Errorf("rate limit wait of %s would exceed the context deadline: %w", delay, context.DeadlineExceeded)
+	}
+
+	l.mu.Lock()
+	l.waits++
+	l.waited += delay
+	stats := rateLimiterStats{Delay: delay, Rate: rate, Waits: l.waits, Waited: l.waited}
+	l.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		l.cancel()
+		return stats, ctx.Err()
+	case <-l.clk.After(delay):
+		return stats, nil
+	}
+}
+
+// observe adapts the limiter to the rate limit headers of a response
+func (l *rateLimiter) observe(resp *http.Response) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clk.Now()
+
+	remaining, hasRemaining := parseRateLimitRemaining(resp)
+	reset, hasReset := parseRateLimitReset(resp, now)
+	if hasRemaining && hasReset && reset > 0 {
+		if remaining == 0 {
+			l.pause(now.Add(reset))
+		} else {
+			l.serverRate = float64(remaining) / reset.Seconds()
+			l.serverUntil = now.Add(reset)
+		}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if retryAfter, ok := parseRetryAfter(resp, now); ok {
+			l.pause(now.Add(retryAfter))
+		}
+	}
+}
+
+// pause holds back all requests until the given time
+func (l *rateLimiter) pause(until time.Time) {
+	if until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// parseRateLimitRemaining returns the X-RateLimit-Remaining header
+func parseRateLimitRemaining(resp *http.Response) (int64, bool) {
+	remaining, err := strconv.ParseInt(strings.TrimSpace(resp.Header.Get(RateLimitRemainingHeader)), 10, 64)
+	if err != nil || remaining < 0 {
+		return 0, false
+	}
+	return remaining, true
+}
+
+// parseRateLimitReset returns the time until the rate limit window resets.
+// Large values are taken to be Unix timestamps rather than seconds.
+func parseRateLimitReset(resp *http.Response, now time.Time) (time.Duration, bool) {
+	reset, err := strconv.ParseInt(strings.TrimSpace(resp.Header.Get(RateLimitResetHeader)), 10, 64)
+	if err != nil || reset < 0 {
+		return 0, false
+	}
+
+	// No window is longer than a day, so anything larger is a timestamp
+	if reset > int64(24*time.Hour/time.Second) {
+		delay := time.Unix(reset, 0).Sub(now)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return time.Duration(reset) * time.Second, true
+}
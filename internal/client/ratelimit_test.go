@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// waitN waits for n requests, failing the test on errors
+func waitN(t *testing.T, ctx context.Context, l *rateLimiter, n int) {
+	for i := 0; i < n; i++ {
+		_, err := l.wait(ctx)
+		assert.NoError(t, err)
+	}
+}
+
+func TestRateLimiter_Burst(t *testing.T) {
+	clk := newFakeClock()
+	l := newRateLimiter(2, clk)
+
+	// A full bucket lets a burst of two through, then paces at 2/s
+	waitN(t, context.Background(), l, 4)
+	assert.Equal(t, []time.Duration{500 * time.Millisecond, 500 * time.Millisecond}, clk.sleeps)
+}
+
+func TestRateLimiter_Refill(t *testing.T) {
+	clk := newFakeClock()
+	l := newRateLimiter(1, clk)
+
+	waitN(t, context.Background(), l, 1)
+	clk.now = clk.now.Add(2 * time.Second)
+
+	// The bucket refilled, but only up to its burst size
+	waitN(t, context.Background(), l, 2)
+	assert.Equal(t, []time.Duration{time.Second}, clk.sleeps)
+}
+
+func TestRateLimiter_Unlimited(t *testing.T) {
+	clk := newFakeClock()
+	l := newRateLimiter(0, clk)
+
+	waitN(t, context.Background(), l, 100)
+	assert.Empty(t, clk.sleeps)
+}
+
+func TestRateLimiter_StopsBeforeContextDeadline(t *testing.T) {
+	clk := newFakeClock()
+	l := newRateLimiter(0.1, clk)
+	ctx, cancel := context.WithDeadline(context.Background(), clk.now.Add(time.Second))
+	defer cancel()
+
+	waitN(t, ctx, l, 1)
+	_, err := l.wait(ctx)
+	assert.ErrorContains(t, err, "would exceed the context deadline")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, clk.sleeps)
+
+	// The unused token was returned to the bucket
+	clk.now = clk.now.Add(10 * time.Second)
+	waitN(t, context.Background(), l, 1)
+	assert.Empty(t, clk.sleeps)
+}
+
+func TestRateLimiter_AdaptsToRemaining(t *testing.T) {
+	clk := newFakeClock()
+	l := newRateLimiter(0, clk)
+
+	// 20 requests left in a 10s window spreads them at 2/s
+	l.observe(response(200, map[string]string{
+		RateLimitRemainingHeader: "20",
+		RateLimitResetHeader:     "10",
+	}))
+	waitN(t, context.Background(), l, 3)
+	assert.Equal(t, []time.Duration{500 * time.Millisecond}, clk.sleeps)
+
+	stats, err := l.wait(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, rateLimiterStats{Delay: 500 * time.Millisecond, Rate: 2, Waits: 2, Waited: time.Second}, stats)
+
+	// Once the window has reset the limit no longer applies
+	clk.now = clk.now.Add(10 * time.Second)
+	clk.sleeps = nil
+	waitN(t, context.Background(), l, 10)
+	assert.Empty(t, clk.sleeps)
+}
+
+func TestRateLimiter_ConfiguredLimitIsCeiling(t *testing.T) {
+	clk := newFakeClock()
+	l := newRateLimiter(1, clk)
+
+	// The API allows more than the configured limit
+	l.observe(response(200, map[string]string{
+		RateLimitRemainingHeader: "1000",
+		RateLimitResetHeader:     "10",
+	}))
+	waitN(t, context.Background(), l, 2)
+	assert.Equal(t, []time.Duration{time.Second}, clk.sleeps)
+}
+
+func TestRateLimiter_PausesWhenExhausted(t *testing.T) {
+	clk := newFakeClock()
+	l := newRateLimiter(0, clk)
+
+	reset := clk.now.Add(30 * time.Second).Unix()
+	l.observe(response(200, map[string]string{
+		RateLimitRemainingHeader: "0",
+		RateLimitResetHeader:     strconv.FormatInt(reset, 10),
+	}))
+	waitN(t, context.Background(), l, 2)
+	assert.Equal(t, []time.Duration{30 * time.Second}, clk.sleeps)
+}
+
+func TestRateLimiter_PausesOnRetryAfter(t *testing.T) {
+	clk := newFakeClock()
+	l := newRateLimiter(0, clk)
+
+	l.observe(response(http.StatusTooManyRequests, map[string]string{"Retry-After": "5"}))
+	waitN(t, context.Background(), l, 1)
+	assert.Equal(t, []time.Duration{5 * time.Second}, clk.sleeps)
+}
+
+func TestClient_RateLimiterShared(t *testing.T) {
+	var mu sync.Mutex
+	var times []time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		times = append(times, time.Now())
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test-token", WithRateLimit(20))
+
+	// Parallel callers share one bucket: 25 requests at 20/s with a burst
+	// of 20 cannot finish in much less than a quarter of a second
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 25; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, client.DeleteGroup(context.Background(), "test-id", ""))
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, times, 25)
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
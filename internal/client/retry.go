@@ -3,16 +3,37 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// JitterMode selects how randomness is added to retry delays, so that many
+// clients hitting the same rate limit do not retry in lock-step
+type JitterMode int
+
+const (
+	// JitterNone uses plain exponential backoff
+	JitterNone JitterMode = iota
+	// JitterFull waits a random duration between zero and the exponential backoff
+	JitterFull
+	// JitterDecorrelated waits a random duration between the initial interval
+	// and three times the previous delay
+	JitterDecorrelated
+)
+
 type RetryConfig struct {
 	MaxRetries      int
 	InitialInterval time.Duration
 	MaxInterval     time.Duration
 	Multiplier      float64
 	MaxElapsedTime  time.Duration
+	Jitter          JitterMode
 }
 
 var DefaultRetryConfig = RetryConfig{
@@ -21,13 +42,27 @@ var DefaultRetryConfig = RetryConfig{
 	MaxInterval:     10 * time.Second,
 	Multiplier:      2.0,
 	MaxElapsedTime:  30 * time.Second,
+	Jitter:          JitterFull,
 }
 
+// clock abstracts the passage of time so retries can be tested without sleeping
+type clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the wall clock
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
 // isRetryable determines if an error should be retried
 func isRetryable(resp *http.Response, err error) bool {
 	if err != nil {
-		// Retry on network errors
-		return true
+		// Retry on network errors, but not when the circuit breaker refused
+		// the request: the API is known to be down
+		return !errors.Is(err, ErrServiceUnavailable)
 	}
 
 	if resp == nil {
@@ -46,51 +81,143 @@ func isRetryable(resp *http.Response, err error) bool {
 	return false
 }
 
-// withRetry executes the given operation with exponential backoff retry logic
-func withRetry(ctx context.Context, config RetryConfig, operation func() (*http.Response, error)) (*http.Response, error) {
-	var resp *http.Response
-	var err error
-	var nextInterval = config.InitialInterval
-	startTime := time.Now()
-
-	for retries := 0; retries <= config.MaxRetries; retries++ {
-		// Check if we've exceeded the maximum elapsed time
-		if time.Since(startTime) > config.MaxElapsedTime {
-			if err != nil {
-				return nil, errors.New("max elapsed time exceeded: " + err.Error())
-			}
-			return nil, errors.New("max elapsed time exceeded")
+// parseRetryAfter returns the delay requested by a Retry-After header, which
+// is either a number of seconds or an HTTP date
+func parseRetryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	value := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := date.Sub(now)
+		if delay < 0 {
+			delay = 0
 		}
+		return delay, true
+	}
+
+	return 0, false
+}
 
-		// If this isn't our first try, wait before retrying
-		if retries > 0 {
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(nextInterval):
-				// Increase the interval for the next iteration
-				nextInterval = time.Duration(float64(nextInterval) * config.Multiplier)
-				if nextInterval > config.MaxInterval {
-					nextInterval = config.MaxInterval
-				}
-			}
+// backoff computes the delay before the given retry (starting at zero)
+type backoff struct {
+	config RetryConfig
+	random func() float64
+	prev   time.Duration
+}
+
+// next returns the delay before retry number n
+func (b *backoff) next(n int) time.Duration {
+	maxInterval := float64(b.config.MaxInterval)
+
+	switch b.config.Jitter {
+	case JitterDecorrelated:
+		if b.prev == 0 {
+			b.prev = b.config.InitialInterval
+		}
+		low := float64(b.config.InitialInterval)
+		high := float64(b.prev) * 3
+		delay := math.Min(maxInterval, low+b.random()*(high-low))
+		b.prev = time.Duration(delay)
+		return b.prev
+	default:
+		delay := float64(b.config.InitialInterval) * math.Pow(b.config.Multiplier, float64(n))
+		delay = math.Min(maxInterval, delay)
+		if b.config.Jitter == JitterFull {
+			delay = b.random() * delay
 		}
+		return time.Duration(delay)
+	}
+}
+
+// describeFailure summarizes a failed attempt for error messages
+func describeFailure(resp *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("status %d", resp.StatusCode)
+}
+
+// attemptError returns the error of a failed attempt, describing the response
+// status if the request itself went through
+func attemptError(resp *http.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	return errors.New(describeFailure(resp, nil))
+}
+
+// discardBody drains and closes the body of a response that will not be used
+func discardBody(resp *http.Response) {
+	if resp != nil && resp.Body != nil {
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+		resp.Body.Close()
+	}
+}
+
+// withRetry executes the given operation with exponential backoff retry logic
+func withRetry(ctx context.Context, config RetryConfig, operation func() (*http.Response, error)) (*http.Response, error) {
+	return retry(ctx, config, realClock{}, rand.Float64, operation)
+}
+
+// retry executes operation until it succeeds with a non-retryable result or
+// the retry budget is spent. Delays follow the configured backoff and jitter,
+// but never undercut a Retry-After header sent by the server. No retry is
+// attempted if its delay would run past MaxElapsedTime or the context deadline.
+// When giving up, the last response (if any) is returned alongside the error,
+// and the caller must close its body.
+func retry(ctx context.Context, config RetryConfig, clk clock, random func() float64, operation func() (*http.Response, error)) (*http.Response, error) {
+	startTime := clk.Now()
+	b := &backoff{config: config, random: random}
+
+	for retries := 0; ; retries++ {
+		resp, err := operation()
 
-		resp, err = operation()
-		
 		// If the operation was successful or we shouldn't retry, return the result
 		if err == nil && (resp == nil || !isRetryable(resp, err)) {
 			return resp, nil
 		}
+		if err != nil && !isRetryable(resp, err) {
+			return resp, err
+		}
 
-		// If this was our last try, return the error
-		if retries == config.MaxRetries {
-			if err != nil {
-				return nil, errors.New("max retries exceeded: " + err.Error())
-			}
-			return resp, errors.New("max retries exceeded")
+		// If this was our last try, return the error along with the last
+		// response, if any, so the caller can report what the API said
+		if retries >= config.MaxRetries {
+			return resp, fmt.Errorf("max retries exceeded: %w", attemptError(resp, err))
 		}
-	}
 
-	return resp, err
-}
\ No newline at end of file
+		delay := b.next(retries)
+		if retryAfter, ok := parseRetryAfter(resp, clk.Now()); ok && retryAfter > delay {
+			delay = retryAfter
+		}
+
+		// Give up early rather than sleep past the retry budget
+		if config.MaxElapsedTime > 0 && clk.Now().Sub(startTime)+delay > config.MaxElapsedTime {
+			return resp, fmt.Errorf("max elapsed time exceeded: %w", attemptError(resp, err))
+		}
+		if deadline, ok := ctx.Deadline(); ok && clk.Now().Add(delay).After(deadline) {
+			return resp, fmt.Errorf("giving up on retry in %s: %w: %w", delay, context.DeadlineExceeded, attemptError(resp, err))
+		}
+
+		discardBody(resp)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-clk.After(delay):
+		}
+	}
+}
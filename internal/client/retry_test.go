@@ -0,0 +1,208 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock advances instantly and records every delay it was asked to wait
+type fakeClock struct {
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+// sequence returns an operation that answers with the given responses in order
+func sequence(responses ...*http.Response) (func() (*http.Response, error), *int) {
+	calls := 0
+	return func() (*http.Response, error) {
+		resp := responses[calls]
+		calls++
+		return resp, nil
+	}, &calls
+}
+
+func response(status int, headers map[string]string) *http.Response {
+	resp := &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader("")),
+	}
+	for k, v := range headers {
+		resp.Header.Set(k, v)
+	}
+	return resp
+}
+
+func fixedRandom(v float64) func() float64 {
+	return func() float64 { return v }
+}
+
+func TestRetry_NoJitterBackoff(t *testing.T) {
+	clk := newFakeClock()
+	config := RetryConfig{MaxRetries: 3, InitialInterval: time.Second, MaxInterval: 3 * time.Second, Multiplier: 2, Jitter: JitterNone}
+	op, calls := sequence(response(503, nil), response(503, nil), response(503, nil), response(200, nil))
+
+	resp, err := retry(context.Background(), config, clk, fixedRandom(0.5), op)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, 4, *calls)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}, clk.sleeps)
+}
+
+func TestRetry_FullJitter(t *testing.T) {
+	clk := newFakeClock()
+	config := RetryConfig{MaxRetries: 2, InitialInterval: time.Second, MaxInterval: time.Minute, Multiplier: 2, Jitter: JitterFull}
+	op, _ := sequence(response(503, nil), response(503, nil), response(200, nil))
+
+	_, err := retry(context.Background(), config, clk, fixedRandom(0.25), op)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{250 * time.Millisecond, 500 * time.Millisecond}, clk.sleeps)
+}
+
+func TestRetry_DecorrelatedJitter(t *testing.T) {
+	clk := newFakeClock()
+	config := RetryConfig{MaxRetries: 3, InitialInterval: time.Second, MaxInterval: 5 * time.Second, Multiplier: 2, Jitter: JitterDecorrelated}
+	op, _ := sequence(response(503, nil), response(503, nil), response(503, nil), response(200, nil))
+
+	_, err := retry(context.Background(), config, clk, fixedRandom(1), op)
+
+	assert.NoError(t, err)
+	// Each delay is three times the previous one, capped at MaxInterval
+	assert.Equal(t, []time.Duration{3 * time.Second, 5 * time.Second, 5 * time.Second}, clk.sleeps)
+}
+
+func TestRetry_RetryAfterSeconds(t *testing.T) {
+	clk := newFakeClock()
+	config := RetryConfig{MaxRetries: 1, InitialInterval: 100 * time.Millisecond, MaxInterval: time.Second, Multiplier: 2, Jitter: JitterFull}
+	op, _ := sequence(response(429, map[string]string{"Retry-After": "7"}), response(200, nil))
+
+	_, err := retry(context.Background(), config, clk, fixedRandom(0.5), op)
+
+	assert.NoError(t, err)
+	// Retry-After wins over the backoff, even beyond MaxInterval
+	assert.Equal(t, []time.Duration{7 * time.Second}, clk.sleeps)
+}
+
+func TestRetry_RetryAfterHTTPDate(t *testing.T) {
+	clk := newFakeClock()
+	config := RetryConfig{MaxRetries: 1, InitialInterval: 100 * time.Millisecond, MaxInterval: time.Second, Multiplier: 2, Jitter: JitterNone}
+	retryAt := clk.now.Add(12 * time.Second).Format(http.TimeFormat)
+	op, _ := sequence(response(503, map[string]string{"Retry-After": retryAt}), response(200, nil))
+
+	_, err := retry(context.Background(), config, clk, fixedRandom(0.5), op)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{12 * time.Second}, clk.sleeps)
+}
+
+func TestRetry_RetryAfterShorterThanBackoff(t *testing.T) {
+	clk := newFakeClock()
+	config := RetryConfig{MaxRetries: 1, InitialInterval: 2 * time.Second, MaxInterval: 10 * time.Second, Multiplier: 2, Jitter: JitterNone}
+	op, _ := sequence(response(429, map[string]string{"Retry-After": "1"}), response(200, nil))
+
+	_, err := retry(context.Background(), config, clk, fixedRandom(0.5), op)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{2 * time.Second}, clk.sleeps)
+}
+
+func TestRetry_StopsBeforeContextDeadline(t *testing.T) {
+	clk := newFakeClock()
+	ctx, cancel := context.WithDeadline(context.Background(), clk.now.Add(5*time.Second))
+	defer cancel()
+
+	config := RetryConfig{MaxRetries: 3, InitialInterval: time.Second, MaxInterval: time.Minute, Multiplier: 2, Jitter: JitterNone}
+	op, calls := sequence(response(429, map[string]string{"Retry-After": "30"}), response(200, nil))
+
+	resp, err := retry(ctx, config, clk, fixedRandom(0.5), op)
+
+	// The last response is handed back so the caller can report it
+	assert.Equal(t, 429, resp.StatusCode)
+	assert.ErrorContains(t, err, "context deadline")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, *calls)
+	assert.Empty(t, clk.sleeps)
+}
+
+func TestRetry_StopsBeforeMaxElapsedTime(t *testing.T) {
+	clk := newFakeClock()
+	config := RetryConfig{MaxRetries: 5, InitialInterval: 2 * time.Second, MaxInterval: time.Minute, Multiplier: 2, MaxElapsedTime: 5 * time.Second, Jitter: JitterNone}
+	op, calls := sequence(response(503, nil), response(503, nil), response(503, nil))
+
+	_, err := retry(context.Background(), config, clk, fixedRandom(0.5), op)
+
+	assert.ErrorContains(t, err, "max elapsed time exceeded")
+	// 2s fits the budget, the following 4s would not
+	assert.Equal(t, 2, *calls)
+	assert.Equal(t, []time.Duration{2 * time.Second}, clk.sleeps)
+}
+
+func TestRetry_MaxRetriesExceeded(t *testing.T) {
+	clk := newFakeClock()
+	config := RetryConfig{MaxRetries: 2, InitialInterval: time.Millisecond, MaxInterval: time.Second, Multiplier: 2, Jitter: JitterNone}
+	op, calls := sequence(response(502, nil), response(502, nil), response(502, nil))
+
+	_, err := retry(context.Background(), config, clk, fixedRandom(0.5), op)
+
+	assert.EqualError(t, err, "max retries exceeded: status 502")
+	assert.Equal(t, 3, *calls)
+}
+
+func TestRetry_WrapsLastError(t *testing.T) {
+	clk := newFakeClock()
+	config := RetryConfig{MaxRetries: 1, InitialInterval: time.Millisecond, MaxInterval: time.Second, Multiplier: 2, Jitter: JitterNone}
+	netErr := errors.New("connection reset")
+
+	_, err := retry(context.Background(), config, clk, fixedRandom(0.5), func() (*http.Response, error) {
+		return nil, netErr
+	})
+
+	assert.EqualError(t, err, "max retries exceeded: connection reset")
+	assert.ErrorIs(t, err, netErr)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{now.Add(time.Minute).Format(http.TimeFormat), time.Minute, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(response(429, map[string]string{"Retry-After": tt.value}), now)
+		assert.Equal(t, tt.ok, ok, tt.value)
+		assert.Equal(t, tt.want, got, tt.value)
+	}
+}
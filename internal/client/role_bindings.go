@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// RoleBinding grants a role to a group on a set of resources.
+//
+// Fields:
+//   - RoleID: Identifier of the granted role
+//   - Bindings: Resources (e.g. business units) the role is granted on
+type RoleBinding struct {
+	RoleID   string   `json:"roleId"`
+	Bindings []string `json:"bindings"`
+}
+
+// ListRoleBindings returns all roles granted to a group, following pagination
+func (c *Client) ListRoleBindings(ctx context.Context, groupID string) ([]RoleBinding, error) {
+	var result []RoleBinding
+	err := withTimeout(ctx, c.timeouts.List, func(ctx context.Context) error {
+		bindings, err := listAll[RoleBinding](ctx, c, fmt.Sprintf("/groups/%s/roles", groupID))
+		if err != nil {
+			return err
+		}
+
+		result = bindings
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// AddRoleBinding grants a role to a group on the given resources.
+// If the group already holds the role, its bindings are replaced.
+func (c *Client) AddRoleBinding(ctx context.Context, groupID string, binding RoleBinding) (*RoleBinding, error) {
+	var result *RoleBinding
+	err := withTimeout(ctx, c.timeouts.Create, func(ctx context.Context) error {
+		data, err := json.Marshal(binding)
+		if err != nil {
+			return fmt.Errorf("failed to marshal role binding payload: %w", err)
+		}
+
+		req, err := c.newRequest(ctx, http.MethodPost, fmt.Sprintf("/groups/%s/roles", groupID), strings.NewReader(string(data)))
+		if err != nil {
+			return err
+		}
+
+		var created RoleBinding
+		if err := c.do(req, &created); err != nil {
+			return err
+		}
+
+		result = &created
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RemoveRoleBinding revokes a role from a group
+func (c *Client) RemoveRoleBinding(ctx context.Context, groupID string, roleID string) error {
+	return withTimeout(ctx, c.timeouts.Delete, func(ctx context.Context) error {
+		req, err := c.newRequest(ctx, http.MethodDelete, fmt.Sprintf("/groups/%s/roles/%s", groupID, roleID), nil)
+		if err != nil {
+			return err
+		}
+
+		return c.do(req, nil)
+	})
+}
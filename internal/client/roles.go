@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Role represents a custom IAM role in the HiiRetail system.
+// A role bundles a set of permissions that can be granted to groups.
+//
+// Fields:
+//   - ID: Unique identifier for the role (generated by the API)
+//   - Title: Human-readable title of the role
+//   - Description: Optional description explaining the role's purpose
+//   - Permissions: Permission identifiers such as "pos.receipt.read"
+//   - ETag: Version of the role as returned by the API, if any
+type Role struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+	ETag        string   `json:"-"`
+}
+
+// CreateRole creates a new custom IAM role
+func (c *Client) CreateRole(ctx context.Context, title string, description string, permissions []string) (*Role, error) {
+	var result *Role
+	err := withTimeout(ctx, c.timeouts.Create, func(ctx context.Context) error {
+		payload := map[string]interface{}{
+			"title":       title,
+			"description": description,
+			"permissions": permissions,
+		}
+
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal role payload: %w", err)
+		}
+
+		req, err := c.newRequest(ctx, http.MethodPost, "/roles", strings.NewReader(string(data)))
+		if err != nil {
+			return err
+		}
+
+		var role Role
+		if err := c.do(req, &role); err != nil {
+			return err
+		}
+
+		result = &role
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetRole retrieves a custom IAM role by ID
+func (c *Client) GetRole(ctx context.Context, id string) (*Role, error) {
+	var result *Role
+	err := withTimeout(ctx, c.timeouts.Read, func(ctx context.Context) error {
+		req, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf("/roles/%s", id), nil)
+		if err != nil {
+			return err
+		}
+
+		var role Role
+		if err := c.do(req, &role); err != nil {
+			return err
+		}
+
+		result = &role
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateRole updates an existing custom IAM role. If etag is set, the update
+// is only applied if the role has not changed since that version was read.
+func (c *Client) UpdateRole(ctx context.Context, id string, title string, description string, permissions []string, etag string) (*Role, error) {
+	var result *Role
+	err := withTimeout(ctx, c.timeouts.Update, func(ctx context.Context) error {
+		payload := map[string]interface{}{
+			"title":       title,
+			"description": description,
+			"permissions": permissions,
+		}
+
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal role payload: %w", err)
+		}
+
+		req, err := c.newRequest(ctx, http.MethodPut, fmt.Sprintf("/roles/%s", id), strings.NewReader(string(data)))
+		if err != nil {
+			return err
+		}
+		setIfMatch(req, etag)
+
+		var role Role
+		if err := c.do(req, &role); err != nil {
+			return err
+		}
+
+		result = &role
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteRole deletes a custom IAM role. If etag is set, the role is only
+// deleted if it has not changed since that version was read.
+func (c *Client) DeleteRole(ctx context.Context, id string, etag string) error {
+	return withTimeout(ctx, c.timeouts.Delete, func(ctx context.Context) error {
+		req, err := c.newRequest(ctx, http.MethodDelete, fmt.Sprintf("/roles/%s", id), nil)
+		if err != nil {
+			return err
+		}
+		setIfMatch(req, etag)
+
+		return c.do(req, nil)
+	})
+}
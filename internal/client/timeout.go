@@ -31,9 +31,11 @@ var DefaultTimeoutConfig = TimeoutConfig{
 	List:    60 * time.Second,
 }
 
-// withTimeout wraps an operation with a timeout based on operation type
+// withTimeout wraps an operation with a timeout based on operation type. A
+// deadline already set on ctx, such as a resource's configured timeout, takes
+// precedence over the fixed timeout.
 func withTimeout(ctx context.Context, timeout time.Duration, operation func(context.Context) error) error {
-	if timeout > 0 {
+	if _, ok := ctx.Deadline(); !ok && timeout > 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, timeout)
 		defer cancel()
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/extenda/terraform-provider-hiiretail-iam/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// addAPIError adds a diagnostic for a failed IAM API call. Failures that do
+// not depend on the kind of object, such as an open circuit breaker or an
+// expired timeout, get a specific summary; anything else keeps the generic
+// summary and the hint about likely causes, which may be empty.
+func addAPIError(diags *diag.Diagnostics, summary string, detail string, hint string, err error) {
+	switch {
+	case errors.Is(err, client.ErrServiceUnavailable):
+		diags.AddError("IAM API Unavailable", fmt.Sprintf("%s The IAM API failed repeatedly, so the provider stopped sending requests to it instead of retrying. Check the service status and run Terraform again once it has recovered. Original error: %s", detail, err))
+	case errors.Is(err, context.DeadlineExceeded):
+		diags.AddError("Operation Timed Out", fmt.Sprintf("%s The operation did not finish within its timeout. Increase it in the resource's timeouts block or the provider's default_timeouts. Original error: %s", detail, err))
+	case client.IsForbidden(err):
+		diags.AddError("Permission Denied", fmt.Sprintf("%s The configured credentials are not allowed to perform this operation. Original error: %s", detail, err))
+	case client.IsRateLimited(err):
+		diags.AddError("API Rate Limit Exceeded", fmt.Sprintf("%s The IAM API kept rejecting requests because of rate limiting. Try again later. Original error: %s", detail, err))
+	default:
+		if hint != "" {
+			detail += " " + hint
+		}
+		diags.AddError(summary, fmt.Sprintf("%s Original error: %s", detail, err))
+	}
+}
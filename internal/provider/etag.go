@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// etagValue converts the ETag returned by the API into its state value.
+// Resources the API returned without an ETag store null.
+func etagValue(etag string) types.String {
+	if etag == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(etag)
+}
+
+// stateETag returns the ETag recorded in state. The planned value cannot be
+// used for this, since it is unknown whenever the resource changes.
+func stateETag(ctx context.Context, state tfsdk.State) (string, diag.Diagnostics) {
+	var etag types.String
+	diags := state.GetAttribute(ctx, path.Root("etag"), &etag)
+	return etag.ValueString(), diags
+}
+
+// addPreconditionFailedError reports an update or delete the API rejected
+// because the resource changed since Terraform last read it
+func addPreconditionFailedError(diags *diag.Diagnostics, resourceType string, detail string, err error) {
+	diags.AddError(
+		fmt.Sprintf("%s Changed Outside Terraform", resourceType),
+		fmt.Sprintf("%s The %s was modified after Terraform last read it, so the change was not applied to avoid "+
+			"overwriting it. Refresh the state with \"terraform plan\", review the differences and apply again. "+
+			"Original error: %s", detail, strings.ToLower(resourceType), err),
+	)
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/extenda/terraform-provider-hiiretail-iam/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &GroupDataSource{}
+
+func NewGroupDataSource() datasource.DataSource {
+	return &GroupDataSource{}
+}
+
+// GroupDataSource defines the implementation of the hiiretail_iam_group data
+// source. It looks up an existing IAM group by name or ID, so that modules can
+// reference groups managed elsewhere.
+type GroupDataSource struct {
+	client client.IClient
+}
+
+// GroupDataSourceModel describes the data source data model for an IAM group.
+type GroupDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+}
+
+func (d *GroupDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group"
+}
+
+func (d *GroupDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up an existing IAM group in HiiRetail by name or ID. Exactly one of name or id must be set.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The group identifier. Set this to look the group up by ID.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("name")),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the group. Set this to look the group up by name.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "The description of the group.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *GroupDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *GroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data GroupDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var group *client.Group
+	if !data.ID.IsNull() {
+		var err error
+		group, err = d.client.GetGroup(ctx, data.ID.ValueString())
+		if err != nil {
+			if client.IsResourceNotFound(err) {
+				resp.Diagnostics.AddAttributeError(path.Root("id"), "Group Not Found",
+					fmt.Sprintf("No IAM group with ID %q exists.", data.ID.ValueString()))
+				return
+			}
+			addAPIError(&resp.Diagnostics, "Failed to Read Group", fmt.Sprintf("Could not read IAM group (ID: %s).", data.ID.ValueString()), "", err)
+			return
+		}
+	} else {
+		name := data.Name.ValueString()
+		groups, err := d.client.FindGroupsByName(ctx, name)
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Failed to Find Group", fmt.Sprintf("Could not search for IAM group %q.", name), "", err)
+			return
+		}
+
+		switch len(groups) {
+		case 0:
+			resp.Diagnostics.AddAttributeError(path.Root("name"), "Group Not Found",
+				fmt.Sprintf("No IAM group named %q exists.", name))
+			return
+		case 1:
+			group = &groups[0]
+		default:
+			ids := make([]string, 0, len(groups))
+			for _, g := range groups {
+				ids = append(ids, g.ID)
+			}
+			resp.Diagnostics.AddAttributeError(path.Root("name"), "Multiple Groups Found",
+				fmt.Sprintf("%d IAM groups are named %q (IDs: %s). Look the group up by id instead.", len(groups), name, strings.Join(ids, ", ")))
+			return
+		}
+	}
+
+	// Map response body to schema
+	data.ID = types.StringValue(group.ID)
+	data.Name = types.StringValue(group.Name)
+	data.Description = types.StringValue(group.Description)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/extenda/terraform-provider-hiiretail-iam/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// readGroupDataSource runs the group data source against the mock client
+// with the given id and name configuration
+func readGroupDataSource(t *testing.T, mockClient *MockClient, id, name interface{}) *datasource.ReadResponse {
+	ctx := context.Background()
+	d := &GroupDataSource{}
+
+	configResp := &datasource.ConfigureResponse{}
+	d.Configure(ctx, datasource.ConfigureRequest{ProviderData: &ProviderData{Client: mockClient}}, configResp)
+	assert.False(t, configResp.Diagnostics.HasError())
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"id":          tftypes.NewValue(tftypes.String, id),
+			"name":        tftypes.NewValue(tftypes.String, name),
+			"description": tftypes.NewValue(tftypes.String, nil),
+		}),
+	}
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+
+	return resp
+}
+
+func TestGroupDataSource_ByName(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("FindGroupsByName", mock.Anything, "developers").Return([]client.Group{
+		{ID: "group-id", Name: "developers", Description: "Development team"},
+	}, nil)
+
+	resp := readGroupDataSource(t, mockClient, nil, "developers")
+
+	assert.False(t, resp.Diagnostics.HasError())
+	var state GroupDataSourceModel
+	_ = resp.State.Get(context.Background(), &state)
+	assert.Equal(t, "group-id", state.ID.ValueString())
+	assert.Equal(t, "Development team", state.Description.ValueString())
+}
+
+func TestGroupDataSource_ByID(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("GetGroup", mock.Anything, "group-id").Return(&client.Group{
+		ID: "group-id", Name: "developers", Description: "Development team",
+	}, nil)
+
+	resp := readGroupDataSource(t, mockClient, "group-id", nil)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	var state GroupDataSourceModel
+	_ = resp.State.Get(context.Background(), &state)
+	assert.Equal(t, "developers", state.Name.ValueString())
+}
+
+func TestGroupDataSource_NoMatch(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("FindGroupsByName", mock.Anything, "developers").Return([]client.Group{}, nil)
+
+	resp := readGroupDataSource(t, mockClient, nil, "developers")
+
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Group Not Found", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestGroupDataSource_MultipleMatches(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("FindGroupsByName", mock.Anything, "developers").Return([]client.Group{
+		{ID: "id-1", Name: "developers"},
+		{ID: "id-2", Name: "developers"},
+	}, nil)
+
+	resp := readGroupDataSource(t, mockClient, nil, "developers")
+
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Multiple Groups Found", resp.Diagnostics.Errors()[0].Summary())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "id-1, id-2")
+}
@@ -0,0 +1,279 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/extenda/terraform-provider-hiiretail-iam/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &GroupMemberResource{}
+var _ resource.ResourceWithImportState = &GroupMemberResource{}
+
+func NewGroupMemberResource() resource.Resource {
+	return &GroupMemberResource{}
+}
+
+// GroupMemberResource defines the implementation of the hiiretail_iam_group_member
+// resource. It is additive: it manages the membership of one user in one group
+// and leaves all other members of the group untouched.
+type GroupMemberResource struct {
+	client client.IClient
+	// timeouts are the provider defaults for the timeouts block
+	timeouts client.TimeoutConfig
+}
+
+// GroupMemberResourceModel describes the resource data model for a single
+// group membership. The ID is the composite "groupID/userID".
+type GroupMemberResourceModel struct {
+	ID       types.String   `tfsdk:"id"`
+	GroupID  types.String   `tfsdk:"group_id"`
+	UserID   types.String   `tfsdk:"user_id"`
+	TenantID types.String   `tfsdk:"tenant_id"`
+	Timeouts *TimeoutsModel `tfsdk:"timeouts"`
+}
+
+func (r *GroupMemberResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_member"
+}
+
+func (r *GroupMemberResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Adds a single user to an IAM group in HiiRetail. Other members of the group are left untouched, so the group can also be managed by other tools.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The membership identifier, in the form `groupID/userID`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group_id": schema.StringAttribute{
+				Description: "The ID of the group. Changing this forces a new membership.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				Description: "The ID of the user to add to the group. Changing this forces a new membership.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"tenant_id": schema.StringAttribute{
+				Description: "The tenant the group belongs to, as configured on the provider when the membership was created.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}
+}
+
+func (r *GroupMemberResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+	r.timeouts = data.Timeouts
+}
+
+func (r *GroupMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data GroupMemberResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts, "create", r.timeouts)
+	defer cancel()
+
+	// Add the user to the group
+	err := r.client.AddGroupMember(ctx, data.GroupID.ValueString(), data.UserID.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Failed to Add Group Member", fmt.Sprintf("Could not add user %s to IAM group (ID: %s).", data.UserID.ValueString(), data.GroupID.ValueString()),
+			"This might be due to an unknown group or user.", err)
+		return
+	}
+
+	data.ID = types.StringValue(compositeID(data.GroupID.ValueString(), data.UserID.ValueString()))
+	data.TenantID = tenantValue(r.client.TenantID())
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GroupMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data GroupMemberResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkTenant(data.TenantID, r.client.TenantID())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts, "read", r.timeouts)
+	defer cancel()
+
+	// Check that the user is still a member of the group
+	isMember, err := r.isMember(ctx, data.GroupID.ValueString(), data.UserID.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Failed to Read Group Member", fmt.Sprintf("Could not read the members of IAM group (ID: %s).", data.GroupID.ValueString()),
+			"This might be due to insufficient permissions or network issues.", err)
+		return
+	}
+	if !isMember {
+		// If the membership no longer exists, remove it from state
+		tflog.Info(ctx, "Group membership no longer exists, removing it from state", map[string]interface{}{
+			"id": data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = types.StringValue(compositeID(data.GroupID.ValueString(), data.UserID.ValueString()))
+	data.TenantID = tenantValue(r.client.TenantID())
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update is never called with changes to group_id or user_id, since both
+// require replacement; it only carries the prior state forward.
+func (r *GroupMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data GroupMemberResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GroupMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data GroupMemberResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkTenant(data.TenantID, r.client.TenantID())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts, "delete", r.timeouts)
+	defer cancel()
+
+	// Remove the user from the group
+	err := r.client.RemoveGroupMember(ctx, data.GroupID.ValueString(), data.UserID.ValueString())
+	if err != nil {
+		if client.IsResourceNotFound(err) {
+			// If the membership is already gone, that's okay
+			tflog.Info(ctx, "Group membership already deleted", map[string]interface{}{
+				"id": data.ID.ValueString(),
+			})
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Failed to Remove Group Member", fmt.Sprintf("Could not remove user %s from IAM group (ID: %s).", data.UserID.ValueString(), data.GroupID.ValueString()), "", err)
+		return
+	}
+}
+
+func (r *GroupMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := splitCompositeID(req.ID, "groupID", "userID")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+	groupID, userID := parts[0], parts[1]
+
+	isMember, err := r.isMember(ctx, groupID, userID)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Client Error", "Unable to read group members during import.", "", err)
+		return
+	}
+	if !isMember {
+		resp.Diagnostics.AddError("Resource Not Found",
+			fmt.Sprintf("User %s is not a member of group %s", userID, groupID))
+		return
+	}
+
+	// Set into state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &GroupMemberResourceModel{
+		ID:       types.StringValue(compositeID(groupID, userID)),
+		GroupID:  types.StringValue(groupID),
+		UserID:   types.StringValue(userID),
+		TenantID: tenantValue(r.client.TenantID()),
+	})...)
+}
+
+// isMember reports whether the user is a member of the group. A group that
+// no longer exists has no members.
+func (r *GroupMemberResource) isMember(ctx context.Context, groupID string, userID string) (bool, error) {
+	members, err := r.client.ListGroupMembers(ctx, groupID)
+	if err != nil {
+		if client.IsResourceNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	for _, member := range members {
+		if member.ID == userID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/extenda/terraform-provider-hiiretail-iam/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGroupMemberResource_Create(t *testing.T) {
+	mockClient := &MockClient{}
+	r := &GroupMemberResource{}
+	s := configureTestResource(t, r, mockClient)
+	ctx := context.Background()
+
+	mockClient.On("AddGroupMember", mock.Anything, "group-id", "user-1").Return(nil)
+
+	plan := tfsdk.Plan{Schema: s}
+	_ = plan.Set(ctx, &GroupMemberResourceModel{
+		ID:       types.StringUnknown(),
+		GroupID:  types.StringValue("group-id"),
+		UserID:   types.StringValue("user-1"),
+		TenantID: types.StringUnknown(),
+	})
+
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: s},
+	}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	mockClient.AssertExpectations(t)
+
+	// Other members of the group are never listed or touched
+	mockClient.AssertNotCalled(t, "ListGroupMembers", mock.Anything, mock.Anything)
+	mockClient.AssertNotCalled(t, "RemoveGroupMember", mock.Anything, mock.Anything, mock.Anything)
+
+	var actualState GroupMemberResourceModel
+	_ = resp.State.Get(ctx, &actualState)
+	assert.Equal(t, "group-id/user-1", actualState.ID.ValueString())
+}
+
+func TestGroupMemberResource_Read_Removed(t *testing.T) {
+	mockClient := &MockClient{}
+	r := &GroupMemberResource{}
+	s := configureTestResource(t, r, mockClient)
+	ctx := context.Background()
+
+	mockClient.On("ListGroupMembers", mock.Anything, "group-id").Return([]client.GroupMember{{ID: "user-2"}}, nil)
+
+	state := tfsdk.State{Schema: s}
+	_ = state.Set(ctx, &GroupMemberResourceModel{
+		ID:       types.StringValue("group-id/user-1"),
+		GroupID:  types.StringValue("group-id"),
+		UserID:   types.StringValue("user-1"),
+		TenantID: types.StringNull(),
+	})
+
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.True(t, resp.State.Raw.IsNull())
+}
+
+func TestGroupMemberResource_Import(t *testing.T) {
+	mockClient := &MockClient{}
+	r := &GroupMemberResource{}
+	s := configureTestResource(t, r, mockClient)
+	ctx := context.Background()
+
+	mockClient.On("ListGroupMembers", mock.Anything, "group-id").Return([]client.GroupMember{{ID: "user-1"}}, nil)
+
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{Schema: s},
+	}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "group-id/user-1"}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+
+	var actualState GroupMemberResourceModel
+	_ = resp.State.Get(ctx, &actualState)
+	assert.Equal(t, "group-id", actualState.GroupID.ValueString())
+	assert.Equal(t, "user-1", actualState.UserID.ValueString())
+}
@@ -0,0 +1,344 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/extenda/terraform-provider-hiiretail-iam/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &GroupMembersResource{}
+var _ resource.ResourceWithImportState = &GroupMembersResource{}
+
+func NewGroupMembersResource() resource.Resource {
+	return &GroupMembersResource{}
+}
+
+// GroupMembersResource defines the implementation of the hiiretail_iam_group_members
+// resource. It is authoritative: it owns the full member list of a group and
+// removes every member that is not listed in the configuration.
+type GroupMembersResource struct {
+	client client.IClient
+	// timeouts are the provider defaults for the timeouts block
+	timeouts client.TimeoutConfig
+}
+
+// GroupMembersResourceModel describes the resource data model for the
+// authoritative member list of a group. The ID is the group ID.
+type GroupMembersResourceModel struct {
+	ID       types.String   `tfsdk:"id"`
+	GroupID  types.String   `tfsdk:"group_id"`
+	Members  types.Set      `tfsdk:"members"`
+	TenantID types.String   `tfsdk:"tenant_id"`
+	Timeouts *TimeoutsModel `tfsdk:"timeouts"`
+}
+
+func (r *GroupMembersResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_members"
+}
+
+func (r *GroupMembersResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the complete member list of an IAM group in HiiRetail. This resource is authoritative: users that are not listed are removed from the group. Do not combine it with group_member resources for the same group.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The identifier of the member list, equal to the group ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group_id": schema.StringAttribute{
+				Description: "The ID of the group whose members are managed. Changing this forces a new resource.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"members": schema.SetAttribute{
+				Description: "The IDs of all users that should be members of the group. An empty set removes all members.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"tenant_id": schema.StringAttribute{
+				Description: "The tenant the group belongs to, as configured on the provider when the member list was created.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}
+}
+
+func (r *GroupMembersResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+	r.timeouts = data.Timeouts
+}
+
+func (r *GroupMembersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data GroupMembersResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts, "create", r.timeouts)
+	defer cancel()
+
+	var members []string
+	resp.Diagnostics.Append(data.Members.ElementsAs(ctx, &members, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make the group's members match the configuration exactly
+	if err := r.reconcile(ctx, data.GroupID.ValueString(), members); err != nil {
+		addAPIError(&resp.Diagnostics, "Failed to Set Group Members", fmt.Sprintf("Could not set the members of IAM group (ID: %s).", data.GroupID.ValueString()),
+			"This might be due to an unknown group or user.", err)
+		return
+	}
+
+	data.ID = types.StringValue(data.GroupID.ValueString())
+	data.TenantID = tenantValue(r.client.TenantID())
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GroupMembersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data GroupMembersResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkTenant(data.TenantID, r.client.TenantID())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts, "read", r.timeouts)
+	defer cancel()
+
+	// Get the current members from HiiRetail
+	members, err := r.client.ListGroupMembers(ctx, data.GroupID.ValueString())
+	if err != nil {
+		if client.IsResourceNotFound(err) {
+			// If the group does not exist, remove it from state
+			tflog.Info(ctx, "Group no longer exists, removing it from state", map[string]interface{}{
+				"group_id": data.GroupID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Failed to Read Group Members", fmt.Sprintf("Could not read the members of IAM group (ID: %s).", data.GroupID.ValueString()),
+			"This might be due to insufficient permissions or network issues.", err)
+		return
+	}
+
+	// Map response body to schema
+	resp.Diagnostics.Append(r.setModel(ctx, &data, data.GroupID.ValueString(), members)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GroupMembersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data GroupMembersResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkTenant(data.TenantID, r.client.TenantID())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts, "update", r.timeouts)
+	defer cancel()
+
+	var members []string
+	resp.Diagnostics.Append(data.Members.ElementsAs(ctx, &members, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make the group's members match the configuration exactly
+	if err := r.reconcile(ctx, data.GroupID.ValueString(), members); err != nil {
+		addAPIError(&resp.Diagnostics, "Failed to Update Group Members", fmt.Sprintf("Could not update the members of IAM group (ID: %s).", data.GroupID.ValueString()),
+			"This might be due to an unknown user or concurrent modifications.", err)
+		return
+	}
+
+	data.TenantID = tenantValue(r.client.TenantID())
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GroupMembersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data GroupMembersResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkTenant(data.TenantID, r.client.TenantID())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts, "delete", r.timeouts)
+	defer cancel()
+
+	var members []string
+	resp.Diagnostics.Append(data.Members.ElementsAs(ctx, &members, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Remove the managed members from the group
+	for _, userID := range members {
+		err := r.client.RemoveGroupMember(ctx, data.GroupID.ValueString(), userID)
+		if err != nil && !client.IsResourceNotFound(err) {
+			addAPIError(&resp.Diagnostics, "Failed to Delete Group Members", fmt.Sprintf("Could not remove user %s from IAM group (ID: %s).", userID, data.GroupID.ValueString()), "", err)
+			return
+		}
+	}
+}
+
+func (r *GroupMembersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Read the current members directly
+	members, err := r.client.ListGroupMembers(ctx, req.ID)
+	if err != nil {
+		if client.IsResourceNotFound(err) {
+			resp.Diagnostics.AddError("Resource Not Found",
+				fmt.Sprintf("Unable to find group %s for import", req.ID))
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Client Error", "Unable to read group members during import.", "", err)
+		return
+	}
+
+	var data GroupMembersResourceModel
+	resp.Diagnostics.Append(r.setModel(ctx, &data, req.ID, members)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set into state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// reconcile adds and removes members until the group contains exactly the
+// desired users
+func (r *GroupMembersResource) reconcile(ctx context.Context, groupID string, desired []string) error {
+	current, err := r.client.ListGroupMembers(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	want := make(map[string]bool, len(desired))
+	for _, userID := range desired {
+		want[userID] = true
+	}
+
+	have := make(map[string]bool, len(current))
+	for _, member := range current {
+		have[member.ID] = true
+	}
+
+	for _, userID := range desired {
+		if have[userID] {
+			continue
+		}
+		if err := r.client.AddGroupMember(ctx, groupID, userID); err != nil {
+			return fmt.Errorf("failed to add user %s: %w", userID, err)
+		}
+	}
+
+	for _, member := range current {
+		if want[member.ID] {
+			continue
+		}
+		tflog.Info(ctx, "Removing unlisted user from group", map[string]interface{}{
+			"group_id": groupID,
+			"user_id":  member.ID,
+		})
+		if err := r.client.RemoveGroupMember(ctx, groupID, member.ID); err != nil && !client.IsResourceNotFound(err) {
+			return fmt.Errorf("failed to remove user %s: %w", member.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// setModel maps the API member list onto the resource model
+func (r *GroupMembersResource) setModel(ctx context.Context, data *GroupMembersResourceModel, groupID string, members []client.GroupMember) diag.Diagnostics {
+	userIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		userIDs = append(userIDs, member.ID)
+	}
+
+	memberSet, diags := types.SetValueFrom(ctx, types.StringType, userIDs)
+
+	data.ID = types.StringValue(groupID)
+	data.GroupID = types.StringValue(groupID)
+	data.Members = memberSet
+	data.TenantID = tenantValue(r.client.TenantID())
+
+	return diags
+}
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/extenda/terraform-provider-hiiretail-iam/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func (m *MockClient) ListGroupMembers(ctx context.Context, groupID string) ([]client.GroupMember, error) {
+	args := m.Called(ctx, groupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]client.GroupMember), args.Error(1)
+}
+
+func (m *MockClient) AddGroupMember(ctx context.Context, groupID string, userID string) error {
+	args := m.Called(ctx, groupID, userID)
+	return args.Error(0)
+}
+
+func (m *MockClient) RemoveGroupMember(ctx context.Context, groupID string, userID string) error {
+	args := m.Called(ctx, groupID, userID)
+	return args.Error(0)
+}
+
+func TestGroupMembersResource_Create_RemovesUnlistedMembers(t *testing.T) {
+	mockClient := &MockClient{}
+	r := &GroupMembersResource{}
+	s := configureTestResource(t, r, mockClient)
+	ctx := context.Background()
+
+	mockClient.On("ListGroupMembers", mock.Anything, "group-id").Return([]client.GroupMember{
+		{ID: "user-1"},
+		{ID: "user-2"},
+	}, nil)
+	mockClient.On("AddGroupMember", mock.Anything, "group-id", "user-3").Return(nil)
+	mockClient.On("RemoveGroupMember", mock.Anything, "group-id", "user-1").Return(nil)
+
+	plan := tfsdk.Plan{Schema: s}
+	_ = plan.Set(ctx, &GroupMembersResourceModel{
+		ID:       types.StringUnknown(),
+		GroupID:  types.StringValue("group-id"),
+		Members:  testStringSet("user-2", "user-3"),
+		TenantID: types.StringUnknown(),
+	})
+
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: s},
+	}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "AddGroupMember", mock.Anything, "group-id", "user-2")
+
+	var actualState GroupMembersResourceModel
+	_ = resp.State.Get(ctx, &actualState)
+	assert.Equal(t, "group-id", actualState.ID.ValueString())
+	assert.Equal(t, testStringSet("user-2", "user-3"), actualState.Members)
+}
+
+func TestGroupMembersResource_Create_Timeouts(t *testing.T) {
+	mockClient := &MockClient{}
+	r := &GroupMembersResource{}
+	s := configureTestResource(t, r, mockClient)
+	ctx := context.Background()
+
+	// Every request of the reconciliation runs under the create timeout
+	start := time.Now()
+	withinCreateTimeout := mock.MatchedBy(func(ctx context.Context) bool {
+		deadline, ok := ctx.Deadline()
+		return ok && deadline.Sub(start) > 9*time.Minute && deadline.Sub(start) <= 10*time.Minute+time.Second
+	})
+	mockClient.On("ListGroupMembers", withinCreateTimeout, "group-id").Return([]client.GroupMember{}, nil)
+	mockClient.On("AddGroupMember", withinCreateTimeout, "group-id", "user-1").Return(nil)
+
+	plan := tfsdk.Plan{Schema: s}
+	_ = plan.Set(ctx, &GroupMembersResourceModel{
+		ID:       types.StringUnknown(),
+		GroupID:  types.StringValue("group-id"),
+		Members:  testStringSet("user-1"),
+		TenantID: types.StringUnknown(),
+		Timeouts: &TimeoutsModel{
+			Create: types.StringValue("10m"),
+			Read:   types.StringNull(),
+			Update: types.StringNull(),
+			Delete: types.StringNull(),
+		},
+	})
+
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: s},
+	}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	mockClient.AssertExpectations(t)
+}
+
+func TestGroupMembersResource_Read(t *testing.T) {
+	mockClient := &MockClient{}
+	r := &GroupMembersResource{}
+	s := configureTestResource(t, r, mockClient)
+	ctx := context.Background()
+
+	mockClient.On("ListGroupMembers", mock.Anything, "group-id").Return([]client.GroupMember{
+		{ID: "user-1"},
+		{ID: "user-4"},
+	}, nil)
+
+	state := tfsdk.State{Schema: s}
+	_ = state.Set(ctx, &GroupMembersResourceModel{
+		ID:       types.StringValue("group-id"),
+		GroupID:  types.StringValue("group-id"),
+		Members:  testStringSet("user-1"),
+		TenantID: types.StringNull(),
+	})
+
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+
+	// Members added outside Terraform show up as drift
+	var actualState GroupMembersResourceModel
+	_ = resp.State.Get(ctx, &actualState)
+	assert.Equal(t, testStringSet("user-1", "user-4"), actualState.Members)
+}
+
+func TestGroupMembersResource_Delete(t *testing.T) {
+	mockClient := &MockClient{}
+	r := &GroupMembersResource{}
+	s := configureTestResource(t, r, mockClient)
+	ctx := context.Background()
+
+	mockClient.On("RemoveGroupMember", mock.Anything, "group-id", "user-1").Return(nil)
+	mockClient.On("RemoveGroupMember", mock.Anything, "group-id", "user-2").Return(&client.ResourceNotFoundError{ResourceType: "groups", ID: "group-id"})
+
+	state := tfsdk.State{Schema: s}
+	_ = state.Set(ctx, &GroupMembersResourceModel{
+		ID:       types.StringValue("group-id"),
+		GroupID:  types.StringValue("group-id"),
+		Members:  testStringSet("user-1", "user-2"),
+		TenantID: types.StringNull(),
+	})
+
+	resp := &resource.DeleteResponse{}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	mockClient.AssertExpectations(t)
+}
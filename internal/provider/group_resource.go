@@ -3,22 +3,27 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/extenda/terraform-provider-hiiretail-iam/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &GroupResource{}
 var _ resource.ResourceWithImportState = &GroupResource{}
+var _ resource.ResourceWithModifyPlan = &GroupResource{}
 
 func NewGroupResource() resource.Resource {
-	return &GroupResource{
-		logger: client.NewLogger(client.LogLevelInfo),
-	}
+	return &GroupResource{}
 }
 
 // GroupResource defines the implementation of the hiiretail_iam_group resource.
@@ -26,7 +31,10 @@ func NewGroupResource() resource.Resource {
 // in the HiiRetail system, ensuring proper state management and error handling.
 type GroupResource struct {
 	client client.IClient
-	logger *client.Logger
+	// adoptExisting is the provider default for adopt_existing
+	adoptExisting bool
+	// timeouts are the provider defaults for the timeouts block
+	timeouts client.TimeoutConfig
 }
 
 // GroupResourceModel describes the resource data model for an IAM group.
@@ -37,6 +45,10 @@ type GroupResourceModel struct {
 	ID          types.String `tfsdk:"id"`
 	Name        types.String `tfsdk:"name"`
 	Description types.String `tfsdk:"description"`
+	TenantID    types.String `tfsdk:"tenant_id"`
+	AdoptExisting types.Bool `tfsdk:"adopt_existing"`
+	ETag        types.String `tfsdk:"etag"`
+	Timeouts    *TimeoutsModel `tfsdk:"timeouts"`
 }
 
 func (r *GroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -50,6 +62,9 @@ func (r *GroupResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"id": schema.StringAttribute{
 				Description: "The group identifier.",
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"name": schema.StringAttribute{
 				Description: "The name of the group. Must be between 3 and 128 characters, start with a letter, and contain only letters, numbers, hyphens (-), and underscores (_).",
@@ -65,6 +80,24 @@ func (r *GroupResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					groupDescriptionValidator{},
 				},
 			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "When creating the group, adopt an existing group with the same name instead of failing: its ID is written to state and its description updated to match. Defaults to the provider's adopt_existing_groups setting.",
+				Optional:    true,
+			},
+			"etag": schema.StringAttribute{
+				Description: "The version of the group last read from HiiRetail. Updates and deletes are rejected if the group was modified since.",
+				Computed:    true,
+			},
+			"tenant_id": schema.StringAttribute{
+				Description: "The tenant the group belongs to, as configured on the provider when the group was created.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
 		},
 	}
 }
@@ -75,18 +108,149 @@ func (r *GroupResource) Configure(ctx context.Context, req resource.ConfigureReq
 		return
 	}
 
-	client, ok := req.ProviderData.(client.IClient)
+	data, ok := req.ProviderData.(*ProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected client.IClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.adoptExisting = data.AdoptExistingGroups
+	r.timeouts = data.Timeouts
+}
+
+// ModifyPlan checks, when a group is created or renamed, whether another group
+// already uses the planned name, so the conflict is reported at plan time
+// rather than halfway through an apply.
+func (r *GroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying, or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan GroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Name.IsUnknown() || plan.Name.IsNull() {
+		return
+	}
+
+	currentID := ""
+	if !req.State.Raw.IsNull() {
+		var state GroupResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// Not renamed, so the name is already ours
+		if state.Name.Equal(plan.Name) {
+			return
+		}
+		currentID = state.ID.ValueString()
+	}
+
+	groups, err := r.client.FindGroupsByName(ctx, plan.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(path.Root("name"), "Could Not Check Group Name",
+			fmt.Sprintf("Could not check whether an IAM group named '%s' already exists. Conflicts will be reported when applying. Original error: %s", plan.Name.ValueString(), err))
+		return
+	}
+
+	// A new group may take over the existing one instead of conflicting
+	if currentID == "" && r.shouldAdopt(plan) && len(groups) > 0 {
+		if len(groups) > 1 {
+			addMultipleGroupsError(&resp.Diagnostics, plan.Name.ValueString(), groups)
+			return
+		}
+		resp.Diagnostics.AddAttributeWarning(path.Root("name"), "Existing Group Will Be Adopted",
+			fmt.Sprintf("An IAM group named '%s' already exists (ID: %s). Applying this plan adopts it instead of creating a new group, and updates its description to match the configuration.", plan.Name.ValueString(), groups[0].ID))
+		return
+	}
+
+	for _, group := range groups {
+		if group.ID != currentID {
+			resp.Diagnostics.AddAttributeError(path.Root("name"), "Group Name Already In Use",
+				groupNameInUseDetail(plan.Name.ValueString(), group.ID))
+			return
+		}
+	}
+}
+
+// shouldAdopt reports whether creating the planned group adopts an existing
+// group with the same name, falling back to the provider default
+func (r *GroupResource) shouldAdopt(data GroupResourceModel) bool {
+	if data.AdoptExisting.IsNull() || data.AdoptExisting.IsUnknown() {
+		return r.adoptExisting
+	}
+	return data.AdoptExisting.ValueBool()
+}
+
+// adoptGroup takes over the existing group named like data, if there is one,
+// updating its description to match. It returns nil if there is nothing to adopt.
+func (r *GroupResource) adoptGroup(ctx context.Context, diags *diag.Diagnostics, data GroupResourceModel) *client.Group {
+	name := data.Name.ValueString()
+	groups, err := r.client.FindGroupsByName(ctx, name)
+	if err != nil {
+		addAPIError(diags, "Failed to Find Group", fmt.Sprintf("Could not search for an existing IAM group named '%s' to adopt.", name), "", err)
+		return nil
+	}
+
+	switch len(groups) {
+	case 0:
+		return nil
+	case 1:
+	default:
+		addMultipleGroupsError(diags, name, groups)
+		return nil
+	}
+
+	// Listings carry no ETag, so read the group to update it conditionally
+	id := groups[0].ID
+	group, err := r.client.GetGroup(ctx, id)
+	if err != nil {
+		addGroupError(diags, "Failed to Adopt Group",
+			fmt.Sprintf("Could not read existing IAM group '%s' (ID: %s) while adopting it.", name, id), "", err)
+		return nil
+	}
+
+	// Only the description is changed, so fields the provider does not
+	// manage are left as they are on hand-made groups
+	if group.Description != data.Description.ValueString() {
+		data.ID = types.StringValue(id)
+		description := data.Description.ValueString()
+		group, err = r.updateGroup(ctx, data, client.GroupPatch{Description: &description}, group.ETag)
+		if err != nil {
+			r.addGroupWriteError(ctx, diags, "Failed to Adopt Group",
+				fmt.Sprintf("Could not update existing IAM group '%s' (ID: %s) while adopting it.", name, id),
+				"This might be due to insufficient permissions or invalid input.", data, err)
+			return nil
+		}
+	}
+
+	tflog.Info(ctx, "Adopted existing group", map[string]interface{}{
+		"name": name,
+		"id":   group.ID,
+	})
+	diags.AddAttributeWarning(path.Root("name"), "Adopted Existing Group",
+		fmt.Sprintf("IAM group '%s' already existed (ID: %s) and is now managed by Terraform instead of being created.", name, group.ID))
+
+	return group
+}
+
+// addMultipleGroupsError reports that name matches several groups, so none
+// can be adopted
+func addMultipleGroupsError(diags *diag.Diagnostics, name string, groups []client.Group) {
+	ids := make([]string, 0, len(groups))
+	for _, g := range groups {
+		ids = append(ids, g.ID)
+	}
+	diags.AddAttributeError(path.Root("name"), "Multiple Groups Found",
+		fmt.Sprintf("%d IAM groups are named '%s' (IDs: %s), so none of them can be adopted. Import the intended group by ID instead.", len(groups), name, strings.Join(ids, ", ")))
 }
 
 func (r *GroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -99,10 +263,27 @@ func (r *GroupResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts, "create", r.timeouts)
+	defer cancel()
+
+	// Adopt an existing group with the same name, if enabled
+	var group *client.Group
+	if r.shouldAdopt(data) {
+		group = r.adoptGroup(ctx, &resp.Diagnostics, data)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Create new group
-	group, err := r.client.CreateGroup(ctx, data.Name.ValueString(), data.Description.ValueString())
+	var err error
+	if group == nil {
+		group, err = r.client.CreateGroup(ctx, data.Name.ValueString(), data.Description.ValueString())
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to Create Group", fmt.Sprintf("Could not create IAM group '%s'. This might be due to a name conflict or invalid input. Original error: %s", data.Name.ValueString(), err))
+		r.addGroupWriteError(ctx, &resp.Diagnostics, "Failed to Create Group",
+			fmt.Sprintf("Could not create IAM group '%s'.", data.Name.ValueString()),
+			"This might be due to a name conflict or invalid input.", data, err)
 		return
 	}
 
@@ -110,6 +291,8 @@ func (r *GroupResource) Create(ctx context.Context, req resource.CreateRequest,
 	data.ID = types.StringValue(group.ID)
 	data.Name = types.StringValue(group.Name)
 	data.Description = types.StringValue(group.Description)
+	data.TenantID = tenantValue(r.client.TenantID())
+	data.ETag = etagValue(group.ETag)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -125,16 +308,28 @@ func (r *GroupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	resp.Diagnostics.Append(checkTenant(data.TenantID, r.client.TenantID())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts, "read", r.timeouts)
+	defer cancel()
+
 	// Get refreshed group value from HiiRetail
 	group, err := r.client.GetGroup(ctx, data.ID.ValueString())
 	if err != nil {
 		if client.IsResourceNotFound(err) {
 			// If the resource does not exist, remove it from state
-			r.logger.Info("Group %s no longer exists", data.ID.ValueString())
+			tflog.Info(ctx, "Group no longer exists, removing it from state", map[string]interface{}{
+				"id": data.ID.ValueString(),
+			})
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Failed to Read Group", fmt.Sprintf("Could not read IAM group (ID: %s). This might be due to insufficient permissions or network issues. Original error: %s", data.ID.ValueString(), err))
+		addGroupError(&resp.Diagnostics, "Failed to Read Group",
+			fmt.Sprintf("Could not read IAM group (ID: %s).", data.ID.ValueString()),
+			"This might be due to insufficient permissions or network issues.", err)
 		return
 	}
 
@@ -142,6 +337,8 @@ func (r *GroupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	data.ID = types.StringValue(group.ID)
 	data.Name = types.StringValue(group.Name)
 	data.Description = types.StringValue(group.Description)
+	data.TenantID = tenantValue(r.client.TenantID())
+	data.ETag = etagValue(group.ETag)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -157,21 +354,84 @@ func (r *GroupResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	// Update existing group
-	group, err := r.client.UpdateGroup(ctx, data.ID.ValueString(), data.Name.ValueString(), data.Description.ValueString())
+	resp.Diagnostics.Append(checkTenant(data.TenantID, r.client.TenantID())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state GroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	etag, diags := stateETag(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The ID never changes; take it from state in case the plan has it unknown
+	data.ID = state.ID
+
+	// Only settings such as timeouts changed; there is nothing to send
+	patch := groupPatch(state, data)
+	if patch.IsEmpty() {
+		data.ETag = etagValue(etag)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts, "update", r.timeouts)
+	defer cancel()
+
+	// Update existing group, unless it changed since it was last read
+	group, err := r.updateGroup(ctx, data, patch, etag)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to Update Group", fmt.Sprintf("Could not update IAM group '%s' (ID: %s). This might be due to concurrent modifications or invalid input. Original error: %s", data.Name.ValueString(), data.ID.ValueString(), err))
+		r.addGroupWriteError(ctx, &resp.Diagnostics, "Failed to Update Group",
+			fmt.Sprintf("Could not update IAM group '%s' (ID: %s).", data.Name.ValueString(), data.ID.ValueString()),
+			"This might be due to concurrent modifications or invalid input.", data, err)
 		return
 	}
 
 	// Map response body to schema
 	data.Name = types.StringValue(group.Name)
 	data.Description = types.StringValue(group.Description)
+	data.TenantID = tenantValue(r.client.TenantID())
+	data.ETag = etagValue(group.ETag)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// groupPatch returns the attributes that differ between state and plan
+func groupPatch(state GroupResourceModel, plan GroupResourceModel) client.GroupPatch {
+	var patch client.GroupPatch
+	if !plan.Name.Equal(state.Name) {
+		name := plan.Name.ValueString()
+		patch.Name = &name
+	}
+	if !plan.Description.Equal(state.Description) {
+		description := plan.Description.ValueString()
+		patch.Description = &description
+	}
+	return patch
+}
+
+// updateGroup sends only the changed attributes with PATCH, so fields of the
+// group the provider does not manage are preserved. APIs without PATCH
+// support get the full group with PUT instead.
+func (r *GroupResource) updateGroup(ctx context.Context, data GroupResourceModel, patch client.GroupPatch, etag string) (*client.Group, error) {
+	group, err := r.client.PatchGroup(ctx, data.ID.ValueString(), patch, etag)
+	if client.IsNotSupported(err) {
+		tflog.Info(ctx, "PATCH is not supported for groups, falling back to PUT", map[string]interface{}{
+			"id": data.ID.ValueString(),
+		})
+		return r.client.UpdateGroup(ctx, data.ID.ValueString(), data.Name.ValueString(), data.Description.ValueString(), etag)
+	}
+	return group, err
+}
+
 func (r *GroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data GroupResourceModel
 
@@ -182,30 +442,93 @@ func (r *GroupResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	// Delete existing group
-	err := r.client.DeleteGroup(ctx, data.ID.ValueString())
+	resp.Diagnostics.Append(checkTenant(data.TenantID, r.client.TenantID())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts, "delete", r.timeouts)
+	defer cancel()
+
+	// Delete existing group, unless it changed since it was last read
+	err := r.client.DeleteGroup(ctx, data.ID.ValueString(), data.ETag.ValueString())
 	if err != nil {
 		if client.IsResourceNotFound(err) {
 			// If the resource is already gone, that's okay
-			r.logger.Info("Group %s already deleted", data.ID.ValueString())
+			tflog.Info(ctx, "Group already deleted", map[string]interface{}{
+				"id": data.ID.ValueString(),
+			})
 			return
 		}
-		resp.Diagnostics.AddError("Failed to Delete Group", fmt.Sprintf("Could not delete IAM group (ID: %s). This might be due to the group having existing members or dependencies. Original error: %s", data.ID.ValueString(), err))
+		addGroupError(&resp.Diagnostics, "Failed to Delete Group",
+			fmt.Sprintf("Could not delete IAM group (ID: %s).", data.ID.ValueString()),
+			"This might be due to the group having existing members or dependencies.", err)
 		return
 	}
 }
 
+// importByNamePrefix marks a group import ID that is a group name, e.g. "name:developers"
+const importByNamePrefix = "name:"
+
+// ImportState imports a group by ID or, with the "name:" prefix, by name.
+// Either form may be qualified with the tenant as "tenant/ID", which must
+// match the tenant the provider is configured for.
 func (r *GroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Read the group data directly
-	group, err := r.client.GetGroup(ctx, req.ID)
-	if err != nil {
-		if client.IsResourceNotFound(err) {
-			resp.Diagnostics.AddError("Resource Not Found", 
-				fmt.Sprintf("Unable to find group %s for import", req.ID))
+	id := req.ID
+
+	// Strip and check the tenant of a composite import ID
+	if strings.Contains(id, compositeIDSeparator) {
+		parts, err := splitCompositeID(id, "tenant", "id")
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+			return
+		}
+
+		if tenantID := r.client.TenantID(); parts[0] != tenantID {
+			resp.Diagnostics.AddError("Tenant Mismatch",
+				fmt.Sprintf("The import ID %q names tenant %q, but the provider is configured for tenant %q. Configure tenant_id on the provider to match.", req.ID, parts[0], tenantID))
+			return
+		}
+		id = parts[1]
+	}
+
+	var group *client.Group
+	if name, ok := strings.CutPrefix(id, importByNamePrefix); ok {
+		groups, err := r.client.FindGroupsByName(ctx, name)
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Client Error", fmt.Sprintf("Unable to search for group %q during import.", name), "", err)
+			return
+		}
+
+		switch len(groups) {
+		case 0:
+			resp.Diagnostics.AddError("Resource Not Found",
+				fmt.Sprintf("Unable to find a group named %q for import", name))
+			return
+		case 1:
+			group = &groups[0]
+		default:
+			ids := make([]string, 0, len(groups))
+			for _, g := range groups {
+				ids = append(ids, g.ID)
+			}
+			resp.Diagnostics.AddError("Multiple Groups Found",
+				fmt.Sprintf("%d IAM groups are named %q (IDs: %s). Import the intended group by ID instead.", len(groups), name, strings.Join(ids, ", ")))
+			return
+		}
+	} else {
+		// Read the group data directly
+		var err error
+		group, err = r.client.GetGroup(ctx, id)
+		if err != nil {
+			if client.IsResourceNotFound(err) {
+				resp.Diagnostics.AddError("Resource Not Found",
+					fmt.Sprintf("Unable to find group %s for import", id))
+				return
+			}
+			addAPIError(&resp.Diagnostics, "Client Error", "Unable to read group during import.", "", err)
 			return
 		}
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read group during import, got error: %s", err))
-		return
 	}
 
 	// Set into state
@@ -213,5 +536,97 @@ func (r *GroupResource) ImportState(ctx context.Context, req resource.ImportStat
 		ID:          types.StringValue(group.ID),
 		Name:        types.StringValue(group.Name),
 		Description: types.StringValue(group.Description),
+		TenantID:    tenantValue(r.client.TenantID()),
 	})...)
-}
\ No newline at end of file
+}
+
+// addGroupWriteError adds diagnostics for a failed create or update. Field
+// violations reported by the API are attributed to the offending attribute,
+// and name conflicts point at the group that already uses the name.
+func (r *GroupResource) addGroupWriteError(ctx context.Context, diags *diag.Diagnostics, summary string, detail string, hint string, data GroupResourceModel, err error) {
+	if client.IsConflict(err) {
+		if existingID := r.findOtherGroupID(ctx, data.Name.ValueString(), data.ID.ValueString()); existingID != "" {
+			diags.AddAttributeError(path.Root("name"), "Group Name Already In Use",
+				detail+" "+groupNameInUseDetail(data.Name.ValueString(), existingID))
+			return
+		}
+	}
+
+	if apiErr, ok := client.AsAPIError(err); ok && len(apiErr.Violations) > 0 {
+		requestID := ""
+		if apiErr.RequestID != "" {
+			requestID = fmt.Sprintf(" (request ID: %s)", apiErr.RequestID)
+		}
+
+		for _, violation := range apiErr.Violations {
+			message := fmt.Sprintf("%s The IAM API rejected %s: %s%s", detail, violation.Field, violation.Message, requestID)
+			if attr, ok := groupViolationPath(violation.Field); ok {
+				diags.AddAttributeError(attr, "Invalid Group", message)
+			} else {
+				diags.AddError("Invalid Group", message)
+			}
+		}
+		return
+	}
+
+	addGroupError(diags, summary, detail, hint, err)
+}
+
+// groupNameInUseDetail explains that name is taken by the group existingID
+// and how to bring that group under management instead
+func groupNameInUseDetail(name string, existingID string) string {
+	return fmt.Sprintf("An IAM group named '%s' already exists (ID: %s). To manage the existing group with Terraform, import it instead:\n\n  terraform import <resource address> %s", name, existingID, existingID)
+}
+
+// findOtherGroupID returns the ID of a group named name other than excludeID,
+// or an empty string if there is none or the lookup fails
+func (r *GroupResource) findOtherGroupID(ctx context.Context, name string, excludeID string) string {
+	groups, err := r.client.FindGroupsByName(ctx, name)
+	if err != nil {
+		tflog.Warn(ctx, "Failed to look up conflicting group", map[string]interface{}{
+			"name":  name,
+			"error": err.Error(),
+		})
+		return ""
+	}
+
+	for _, group := range groups {
+		if group.ID != excludeID {
+			return group.ID
+		}
+	}
+
+	return ""
+}
+
+// groupViolationPath maps a field reported by the API, such as "name" or
+// "group.description", to the matching resource attribute
+func groupViolationPath(field string) (path.Path, bool) {
+	field = strings.ToLower(field)
+	if i := strings.LastIndexAny(field, "./"); i >= 0 {
+		field = field[i+1:]
+	}
+
+	switch field {
+	case "name", "description":
+		return path.Root(field), true
+	}
+
+	return path.Empty(), false
+}
+
+// addGroupError adds a diagnostic for a failed group API call. Errors the API
+// identified get a specific summary; anything else keeps the generic summary
+// and the hint about likely causes.
+func addGroupError(diags *diag.Diagnostics, summary string, detail string, hint string, err error) {
+	switch {
+	case client.IsPreconditionFailed(err):
+		addPreconditionFailedError(diags, "Group", detail, err)
+	case client.IsValidation(err):
+		diags.AddError("Invalid Group", fmt.Sprintf("%s The IAM API rejected the group as invalid. Original error: %s", detail, err))
+	case client.IsConflict(err):
+		diags.AddError("Group Conflict", fmt.Sprintf("%s The group conflicts with an existing group. Original error: %s", detail, err))
+	default:
+		addAPIError(diags, summary, detail, hint, err)
+	}
+}
@@ -2,13 +2,20 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/extenda/terraform-provider-hiiretail-iam/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -17,13 +24,14 @@ import (
 type IClient interface {
 	CreateGroup(ctx context.Context, name string, description string) (*client.Group, error)
 	GetGroup(ctx context.Context, id string) (*client.Group, error)
-	UpdateGroup(ctx context.Context, id string, name string, description string) (*client.Group, error)
-	DeleteGroup(ctx context.Context, id string) error
+	UpdateGroup(ctx context.Context, id string, name string, description string, etag string) (*client.Group, error)
+	DeleteGroup(ctx context.Context, id string, etag string) error
 }
 
 // MockClient is a mock implementation of the IClient interface
 type MockClient struct {
 	mock.Mock
+	tenantID string
 }
 
 func NewMockClient() client.Client {
@@ -47,19 +55,68 @@ func (m *MockClient) GetGroup(ctx context.Context, id string) (*client.Group, er
 	return args.Get(0).(*client.Group), args.Error(1)
 }
 
-func (m *MockClient) UpdateGroup(ctx context.Context, id string, name string, description string) (*client.Group, error) {
-	args := m.Called(ctx, id, name, description)
+func (m *MockClient) UpdateGroup(ctx context.Context, id string, name string, description string, etag string) (*client.Group, error) {
+	args := m.Called(ctx, id, name, description, etag)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*client.Group), args.Error(1)
 }
 
-func (m *MockClient) DeleteGroup(ctx context.Context, id string) error {
-	args := m.Called(ctx, id)
+func (m *MockClient) PatchGroup(ctx context.Context, id string, patch client.GroupPatch, etag string) (*client.Group, error) {
+	args := m.Called(ctx, id, patch, etag)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*client.Group), args.Error(1)
+}
+
+func (m *MockClient) DeleteGroup(ctx context.Context, id string, etag string) error {
+	args := m.Called(ctx, id, etag)
 	return args.Error(0)
 }
 
+func (m *MockClient) ListGroups(ctx context.Context, opts client.ListGroupsOptions) ([]client.Group, error) {
+	args := m.Called(ctx, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]client.Group), args.Error(1)
+}
+
+func (m *MockClient) FindGroupsByName(ctx context.Context, name string) ([]client.Group, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]client.Group), args.Error(1)
+}
+
+func (m *MockClient) TenantID() string {
+	return m.tenantID
+}
+
+// configureTestResource configures r with the given mock client and returns
+// its schema
+func configureTestResource(t *testing.T, r resource.ResourceWithConfigure, mockClient *MockClient) schema.Schema {
+	return configureTestResourceWith(t, r, &ProviderData{Client: mockClient})
+}
+
+// configureTestResourceWith configures r with the given provider data, for
+// tests that need provider defaults such as timeouts, and returns its schema
+func configureTestResourceWith(t *testing.T, r resource.ResourceWithConfigure, data *ProviderData) schema.Schema {
+	configResp := &resource.ConfigureResponse{}
+	r.Configure(context.Background(), resource.ConfigureRequest{
+		ProviderData: data,
+	}, configResp)
+	assert.False(t, configResp.Diagnostics.HasError())
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, schemaResp)
+
+	return schemaResp.Schema
+}
+
 func TestNewGroupResource(t *testing.T) {
 	r := NewGroupResource()
 	assert.NotNil(t, r)
@@ -80,6 +137,8 @@ func TestGroupResourceSchema(t *testing.T) {
 	assert.True(t, idAttr.Computed)
 	assert.False(t, idAttr.Required)
 	assert.False(t, idAttr.Optional)
+	// Kept in the plan so updates know which group to change
+	assert.Len(t, idAttr.PlanModifiers, 1)
 
 	// Check name attribute
 	assert.Contains(t, response.Schema.Attributes, "name")
@@ -112,7 +171,7 @@ func TestGroupResource_Create(t *testing.T) {
 	// Configure the resource with the mock client
 	configResp := &resource.ConfigureResponse{}
 	r.Configure(context.Background(), resource.ConfigureRequest{
-		ProviderData: mockClient,
+		ProviderData: &ProviderData{Client: mockClient},
 	}, configResp)
 	assert.False(t, configResp.Diagnostics.HasError())
 
@@ -162,7 +221,7 @@ func TestGroupResource_Read(t *testing.T) {
 
 	configResp := &resource.ConfigureResponse{}
 	r.Configure(context.Background(), resource.ConfigureRequest{
-		ProviderData: mockClient,
+		ProviderData: &ProviderData{Client: mockClient},
 	}, configResp)
 	assert.False(t, configResp.Diagnostics.HasError())
 
@@ -217,7 +276,7 @@ func TestGroupResource_Update(t *testing.T) {
 
 	configResp := &resource.ConfigureResponse{}
 	r.Configure(context.Background(), resource.ConfigureRequest{
-		ProviderData: mockClient,
+		ProviderData: &ProviderData{Client: mockClient},
 	}, configResp)
 	assert.False(t, configResp.Diagnostics.HasError())
 
@@ -227,7 +286,10 @@ func TestGroupResource_Update(t *testing.T) {
 		Description: "updated description",
 	}
 
-	mockClient.On("UpdateGroup", mock.Anything, "test-id", "updated-group", "updated description").Return(expectedGroup, nil)
+	mockClient.On("PatchGroup", mock.Anything, "test-id", client.GroupPatch{
+		Name:        stringPointer("updated-group"),
+		Description: stringPointer("updated description"),
+	}, "").Return(expectedGroup, nil)
 
 	ctx := context.Background()
 
@@ -283,11 +345,11 @@ func TestGroupResource_Delete(t *testing.T) {
 
 	configResp := &resource.ConfigureResponse{}
 	r.Configure(context.Background(), resource.ConfigureRequest{
-		ProviderData: mockClient,
+		ProviderData: &ProviderData{Client: mockClient},
 	}, configResp)
 	assert.False(t, configResp.Diagnostics.HasError())
 
-	mockClient.On("DeleteGroup", mock.Anything, "test-id").Return(nil)
+	mockClient.On("DeleteGroup", mock.Anything, "test-id", "").Return(nil)
 
 	ctx := context.Background()
 
@@ -317,13 +379,353 @@ func TestGroupResource_Delete(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestGroupResource_Delete_Timeouts(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeouts *TimeoutsModel
+		want     time.Duration
+	}{
+		{"provider default", nil, 20 * time.Minute},
+		{"timeouts block", &TimeoutsModel{
+			Create: types.StringNull(),
+			Read:   types.StringNull(),
+			Update: types.StringNull(),
+			Delete: types.StringValue("45m"),
+		}, 45 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			r := &GroupResource{}
+
+			defaults := client.DefaultTimeoutConfig
+			defaults.Delete = 20 * time.Minute
+			s := configureTestResourceWith(t, r, &ProviderData{Client: mockClient, Timeouts: defaults})
+
+			// The effective timeout reaches the client as the context deadline
+			start := time.Now()
+			mockClient.On("DeleteGroup", mock.MatchedBy(func(ctx context.Context) bool {
+				deadline, ok := ctx.Deadline()
+				return ok && deadline.Sub(start) > tt.want-time.Minute && deadline.Sub(start) <= tt.want+time.Second
+			}), "test-id", "").Return(nil)
+
+			ctx := context.Background()
+			state := tfsdk.State{Schema: s}
+			diags := state.Set(ctx, &GroupResourceModel{
+				ID:          types.StringValue("test-id"),
+				Name:        types.StringValue("test-group"),
+				Description: types.StringValue("test description"),
+				Timeouts:    tt.timeouts,
+			})
+			assert.False(t, diags.HasError())
+
+			resp := &resource.DeleteResponse{}
+			r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+
+			assert.False(t, resp.Diagnostics.HasError())
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGroupResource_Read_TimedOut(t *testing.T) {
+	mockClient := &MockClient{}
+	r := &GroupResource{}
+	s := configureTestResourceWith(t, r, &ProviderData{Client: mockClient, Timeouts: client.DefaultTimeoutConfig})
+	ctx := context.Background()
+
+	mockClient.On("GetGroup", mock.Anything, "test-id").Return(nil, context.DeadlineExceeded)
+
+	state := tfsdk.State{Schema: s}
+	_ = state.Set(ctx, &GroupResourceModel{
+		ID:          types.StringValue("test-id"),
+		Name:        types.StringValue("test-group"),
+		Description: types.StringValue("test description"),
+	})
+
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Operation Timed Out", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestGroupResource_Read_RetriesCutShortByTimeout(t *testing.T) {
+	// The API asks for a retry later than the read timeout allows
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "20")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	r := &GroupResource{}
+	s := configureTestResourceWith(t, r, &ProviderData{Client: client.NewClient(srv.URL, "test-token"), Timeouts: client.DefaultTimeoutConfig})
+	ctx := context.Background()
+
+	state := tfsdk.State{Schema: s}
+	_ = state.Set(ctx, &GroupResourceModel{
+		ID:          types.StringValue("test-id"),
+		Name:        types.StringValue("test-group"),
+		Description: types.StringValue("test description"),
+		Timeouts: &TimeoutsModel{
+			Create: types.StringNull(),
+			Read:   types.StringValue("10s"),
+			Update: types.StringNull(),
+			Delete: types.StringNull(),
+		},
+	})
+
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+	assert.Len(t, resp.Diagnostics.Errors(), 1)
+	assert.Equal(t, "Operation Timed Out", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestGroupResource_Read_ServiceUnavailable(t *testing.T) {
+	mockClient := &MockClient{}
+	r := &GroupResource{}
+
+	configResp := &resource.ConfigureResponse{}
+	r.Configure(context.Background(), resource.ConfigureRequest{
+		ProviderData: &ProviderData{Client: mockClient},
+	}, configResp)
+
+	mockClient.On("GetGroup", mock.Anything, "test-id").Return(nil, fmt.Errorf("%w: 5 consecutive requests failed", client.ErrServiceUnavailable))
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	_ = state.Set(ctx, &GroupResourceModel{
+		ID:          types.StringValue("test-id"),
+		Name:        types.StringValue("test-group"),
+		Description: types.StringValue("test description"),
+	})
+
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+	assert.Len(t, resp.Diagnostics.Errors(), 1)
+	assert.Equal(t, "IAM API Unavailable", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestGroupResource_Update_UnknownID(t *testing.T) {
+	ctx := context.Background()
+
+	newState := func(s schema.Schema) tfsdk.State {
+		state := tfsdk.State{Schema: s}
+		_ = state.Set(ctx, &GroupResourceModel{
+			ID:          types.StringValue("test-id"),
+			Name:        types.StringValue("test-group"),
+			Description: types.StringValue("old description"),
+			ETag:        types.StringValue(`"v1"`),
+		})
+		return state
+	}
+	// The framework plans computed attributes without a plan modifier as unknown
+	newPlan := func(s schema.Schema, description string, timeouts *TimeoutsModel) tfsdk.Plan {
+		plan := tfsdk.Plan{Schema: s}
+		_ = plan.Set(ctx, &GroupResourceModel{
+			ID:          types.StringUnknown(),
+			Name:        types.StringValue("test-group"),
+			Description: types.StringValue(description),
+			ETag:        types.StringUnknown(),
+			Timeouts:    timeouts,
+		})
+		return plan
+	}
+
+	t.Run("changes are sent to the group in state", func(t *testing.T) {
+		mockClient := &MockClient{}
+		r := &GroupResource{}
+		s := configureTestResource(t, r, mockClient)
+		mockClient.On("PatchGroup", mock.Anything, "test-id", client.GroupPatch{Description: stringPointer("new description")}, `"v1"`).
+			Return(&client.Group{ID: "test-id", Name: "test-group", Description: "new description", ETag: `"v2"`}, nil)
+
+		state := newState(s)
+		resp := &resource.UpdateResponse{State: state}
+		r.Update(ctx, resource.UpdateRequest{State: state, Plan: newPlan(s, "new description", nil)}, resp)
+
+		assert.False(t, resp.Diagnostics.HasError())
+		mockClient.AssertExpectations(t)
+
+		var actualState GroupResourceModel
+		_ = resp.State.Get(ctx, &actualState)
+		assert.Equal(t, types.StringValue("test-id"), actualState.ID)
+	})
+
+	t.Run("timeouts only change keeps the ID", func(t *testing.T) {
+		mockClient := &MockClient{}
+		r := &GroupResource{}
+		s := configureTestResource(t, r, mockClient)
+
+		state := newState(s)
+		resp := &resource.UpdateResponse{State: state}
+		r.Update(ctx, resource.UpdateRequest{State: state, Plan: newPlan(s, "old description", &TimeoutsModel{
+			Create: types.StringNull(),
+			Read:   types.StringNull(),
+			Update: types.StringValue("5m"),
+			Delete: types.StringNull(),
+		})}, resp)
+
+		assert.False(t, resp.Diagnostics.HasError())
+		mockClient.AssertNotCalled(t, "PatchGroup", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+		var actualState GroupResourceModel
+		_ = resp.State.Get(ctx, &actualState)
+		assert.Equal(t, types.StringValue("test-id"), actualState.ID)
+		assert.Equal(t, types.StringValue(`"v1"`), actualState.ETag)
+	})
+}
+
+func TestGroupResource_Update_ETag(t *testing.T) {
+	ctx := context.Background()
+	r := &GroupResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	_ = state.Set(ctx, &GroupResourceModel{
+		ID:          types.StringValue("test-id"),
+		Name:        types.StringValue("old-name"),
+		Description: types.StringValue("test description"),
+		ETag:        types.StringValue(`"v1"`),
+	})
+
+	// The planned ETag is unknown, since the update changes it
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	_ = plan.Set(ctx, &GroupResourceModel{
+		ID:          types.StringValue("test-id"),
+		Name:        types.StringValue("new-name"),
+		Description: types.StringValue("test description"),
+		ETag:        types.StringUnknown(),
+	})
+
+	t.Run("current version", func(t *testing.T) {
+		mockClient := &MockClient{}
+		r.Configure(ctx, resource.ConfigureRequest{ProviderData: &ProviderData{Client: mockClient}}, &resource.ConfigureResponse{})
+		mockClient.On("PatchGroup", mock.Anything, "test-id", client.GroupPatch{Name: stringPointer("new-name")}, `"v1"`).Return(&client.Group{
+			ID:          "test-id",
+			Name:        "new-name",
+			Description: "test description",
+			ETag:        `"v2"`,
+		}, nil)
+
+		resp := &resource.UpdateResponse{State: state}
+		r.Update(ctx, resource.UpdateRequest{State: state, Plan: plan}, resp)
+
+		assert.False(t, resp.Diagnostics.HasError())
+		var actualState GroupResourceModel
+		_ = resp.State.Get(ctx, &actualState)
+		assert.Equal(t, `"v2"`, actualState.ETag.ValueString())
+	})
+
+	t.Run("changed outside Terraform", func(t *testing.T) {
+		mockClient := &MockClient{}
+		r.Configure(ctx, resource.ConfigureRequest{ProviderData: &ProviderData{Client: mockClient}}, &resource.ConfigureResponse{})
+		mockClient.On("PatchGroup", mock.Anything, "test-id", client.GroupPatch{Name: stringPointer("new-name")}, `"v1"`).Return(nil, &client.APIError{
+			StatusCode: 412,
+			Message:    "Precondition Failed",
+		})
+
+		resp := &resource.UpdateResponse{State: state}
+		r.Update(ctx, resource.UpdateRequest{State: state, Plan: plan}, resp)
+
+		assert.Len(t, resp.Diagnostics.Errors(), 1)
+		assert.Equal(t, "Group Changed Outside Terraform", resp.Diagnostics.Errors()[0].Summary())
+		assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "terraform plan")
+	})
+}
+
+func TestGroupResource_Update_Patch(t *testing.T) {
+	ctx := context.Background()
+	r := &GroupResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	_ = state.Set(ctx, &GroupResourceModel{
+		ID:          types.StringValue("test-id"),
+		Name:        types.StringValue("test-group"),
+		Description: types.StringValue("old description"),
+		ETag:        types.StringValue(`"v1"`),
+	})
+
+	newPlan := func(description string) tfsdk.Plan {
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+		_ = plan.Set(ctx, &GroupResourceModel{
+			ID:          types.StringValue("test-id"),
+			Name:        types.StringValue("test-group"),
+			Description: types.StringValue(description),
+			ETag:        types.StringUnknown(),
+		})
+		return plan
+	}
+	updated := &client.Group{ID: "test-id", Name: "test-group", Description: "new description", ETag: `"v2"`}
+
+	t.Run("only changed attributes are sent", func(t *testing.T) {
+		mockClient := &MockClient{}
+		r.Configure(ctx, resource.ConfigureRequest{ProviderData: &ProviderData{Client: mockClient}}, &resource.ConfigureResponse{})
+		mockClient.On("PatchGroup", mock.Anything, "test-id", client.GroupPatch{Description: stringPointer("new description")}, `"v1"`).Return(updated, nil)
+
+		resp := &resource.UpdateResponse{State: state}
+		r.Update(ctx, resource.UpdateRequest{State: state, Plan: newPlan("new description")}, resp)
+
+		assert.False(t, resp.Diagnostics.HasError())
+		mockClient.AssertExpectations(t)
+		mockClient.AssertNotCalled(t, "UpdateGroup", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	for _, status := range []int{405, 501} {
+		t.Run(fmt.Sprintf("falls back to PUT on %d", status), func(t *testing.T) {
+			mockClient := &MockClient{}
+			r.Configure(ctx, resource.ConfigureRequest{ProviderData: &ProviderData{Client: mockClient}}, &resource.ConfigureResponse{})
+			mockClient.On("PatchGroup", mock.Anything, "test-id", mock.Anything, `"v1"`).Return(nil, &client.APIError{StatusCode: status})
+			mockClient.On("UpdateGroup", mock.Anything, "test-id", "test-group", "new description", `"v1"`).Return(updated, nil)
+
+			resp := &resource.UpdateResponse{State: state}
+			r.Update(ctx, resource.UpdateRequest{State: state, Plan: newPlan("new description")}, resp)
+
+			assert.False(t, resp.Diagnostics.HasError())
+			mockClient.AssertExpectations(t)
+
+			var actualState GroupResourceModel
+			_ = resp.State.Get(ctx, &actualState)
+			assert.Equal(t, `"v2"`, actualState.ETag.ValueString())
+		})
+	}
+
+	t.Run("nothing to send", func(t *testing.T) {
+		mockClient := &MockClient{}
+		r.Configure(ctx, resource.ConfigureRequest{ProviderData: &ProviderData{Client: mockClient}}, &resource.ConfigureResponse{})
+
+		resp := &resource.UpdateResponse{State: state}
+		r.Update(ctx, resource.UpdateRequest{State: state, Plan: newPlan("old description")}, resp)
+
+		assert.False(t, resp.Diagnostics.HasError())
+		mockClient.AssertNotCalled(t, "PatchGroup", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+		var actualState GroupResourceModel
+		_ = resp.State.Get(ctx, &actualState)
+		assert.Equal(t, `"v1"`, actualState.ETag.ValueString())
+	})
+}
+
+// stringPointer returns a pointer to s
+func stringPointer(s string) *string {
+	return &s
+}
+
 func TestGroupResource_Import(t *testing.T) {
 	mockClient := &MockClient{}
 	r := &GroupResource{}
 
 	configResp := &resource.ConfigureResponse{}
 	r.Configure(context.Background(), resource.ConfigureRequest{
-		ProviderData: mockClient,
+		ProviderData: &ProviderData{Client: mockClient},
 	}, configResp)
 	assert.False(t, configResp.Diagnostics.HasError())
 
@@ -364,4 +766,570 @@ func TestGroupResource_Import(t *testing.T) {
 	assert.Equal(t, expectedGroup.Description, actualState.Description.ValueString())
 	
 	mockClient.AssertExpectations(t)
-}
\ No newline at end of file
+}
+
+func TestGroupResource_TenantMismatch(t *testing.T) {
+	mockClient := &MockClient{tenantID: "other-tenant"}
+	r := &GroupResource{}
+
+	configResp := &resource.ConfigureResponse{}
+	r.Configure(context.Background(), resource.ConfigureRequest{
+		ProviderData: &ProviderData{Client: mockClient},
+	}, configResp)
+	assert.False(t, configResp.Diagnostics.HasError())
+
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+	}
+	_ = state.Set(ctx, &GroupResourceModel{
+		ID:          types.StringValue("test-id"),
+		Name:        types.StringValue("test-group"),
+		Description: types.StringValue("test description"),
+		TenantID:    types.StringValue("acme"),
+	})
+
+	resp := &resource.ReadResponse{
+		State: state,
+	}
+
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+	// The client must not be called for a resource of another tenant
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Tenant Mismatch", resp.Diagnostics.Errors()[0].Summary())
+	mockClient.AssertNotCalled(t, "GetGroup", mock.Anything, mock.Anything)
+}
+
+func TestGroupResource_Create_RecordsTenant(t *testing.T) {
+	mockClient := &MockClient{tenantID: "acme"}
+	r := &GroupResource{}
+
+	mockClient.On("CreateGroup", mock.Anything, "test-group", "test description").Return(&client.Group{
+		ID:          "test-id",
+		Name:        "test-group",
+		Description: "test description",
+	}, nil)
+
+	configResp := &resource.ConfigureResponse{}
+	r.Configure(context.Background(), resource.ConfigureRequest{
+		ProviderData: &ProviderData{Client: mockClient},
+	}, configResp)
+	assert.False(t, configResp.Diagnostics.HasError())
+
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+	}
+	_ = plan.Set(ctx, &GroupResourceModel{
+		Name:        types.StringValue("test-group"),
+		Description: types.StringValue("test description"),
+		TenantID:    types.StringUnknown(),
+	})
+
+	createResp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+
+	assert.False(t, createResp.Diagnostics.HasError())
+
+	var actualState GroupResourceModel
+	_ = createResp.State.Get(ctx, &actualState)
+	assert.Equal(t, "acme", actualState.TenantID.ValueString())
+}
+
+func TestGroupResource_Create_APIErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantSummary string
+	}{
+		{
+			name:        "Forbidden",
+			err:         &client.APIError{StatusCode: 403, Message: "missing permission iam.group.create"},
+			wantSummary: "Permission Denied",
+		},
+		{
+			name:        "Rate limited",
+			err:         &client.APIError{StatusCode: 429},
+			wantSummary: "API Rate Limit Exceeded",
+		},
+		{
+			name:        "Validation",
+			err:         &client.APIError{StatusCode: 400, Message: "invalid group"},
+			wantSummary: "Invalid Group",
+		},
+		{
+			name:        "Unknown error",
+			err:         assert.AnError,
+			wantSummary: "Failed to Create Group",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			r := &GroupResource{}
+
+			mockClient.On("CreateGroup", mock.Anything, "test-group", "test description").Return(nil, tt.err)
+
+			configResp := &resource.ConfigureResponse{}
+			r.Configure(context.Background(), resource.ConfigureRequest{
+				ProviderData: &ProviderData{Client: mockClient},
+			}, configResp)
+
+			ctx := context.Background()
+
+			schemaResp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+			plan := tfsdk.Plan{
+				Schema: schemaResp.Schema,
+			}
+			_ = plan.Set(ctx, &GroupResourceModel{
+				Name:        types.StringValue("test-group"),
+				Description: types.StringValue("test description"),
+				TenantID:    types.StringUnknown(),
+			})
+
+			createResp := &resource.CreateResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema},
+			}
+
+			r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+
+			assert.True(t, createResp.Diagnostics.HasError())
+			assert.Equal(t, tt.wantSummary, createResp.Diagnostics.Errors()[0].Summary())
+			assert.Contains(t, createResp.Diagnostics.Errors()[0].Detail(), tt.err.Error())
+		})
+	}
+}
+
+// testGroupCreate runs Create for test-group against mockClient and returns
+// the response. adopt is the adopt_existing attribute, adoptDefault the
+// provider default.
+func testGroupCreate(t *testing.T, mockClient *MockClient, adopt types.Bool, adoptDefault bool) *resource.CreateResponse {
+	r := &GroupResource{}
+
+	configResp := &resource.ConfigureResponse{}
+	r.Configure(context.Background(), resource.ConfigureRequest{
+		ProviderData: &ProviderData{Client: mockClient, AdoptExistingGroups: adoptDefault},
+	}, configResp)
+	assert.False(t, configResp.Diagnostics.HasError())
+
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+	}
+	_ = plan.Set(ctx, &GroupResourceModel{
+		ID:            types.StringUnknown(),
+		Name:          types.StringValue("test-group"),
+		Description:   types.StringValue("test description"),
+		TenantID:      types.StringUnknown(),
+		AdoptExisting: adopt,
+	})
+
+	createResp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+
+	return createResp
+}
+
+func TestGroupResource_Create_FieldViolations(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("CreateGroup", mock.Anything, "test-group", "test description").Return(nil, &client.APIError{
+		StatusCode: 400,
+		Message:    "invalid group",
+		RequestID:  "req-123",
+		Violations: []client.FieldViolation{
+			{Field: "name", Message: "contains a reserved word"},
+			{Field: "group.description", Message: "must not contain URLs"},
+			{Field: "labels", Message: "unsupported"},
+		},
+	})
+
+	resp := testGroupCreate(t, mockClient, types.BoolNull(), false)
+
+	errs := resp.Diagnostics.Errors()
+	assert.Len(t, errs, 3)
+
+	assert.Equal(t, path.Root("name"), errs[0].(diag.DiagnosticWithPath).Path())
+	assert.Contains(t, errs[0].Detail(), "contains a reserved word")
+	assert.Contains(t, errs[0].Detail(), "req-123")
+
+	assert.Equal(t, path.Root("description"), errs[1].(diag.DiagnosticWithPath).Path())
+	assert.Contains(t, errs[1].Detail(), "must not contain URLs")
+
+	// Fields without a matching attribute are reported without a path
+	_, hasPath := errs[2].(diag.DiagnosticWithPath)
+	assert.False(t, hasPath)
+}
+
+func TestGroupResource_Create_NameConflict(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("CreateGroup", mock.Anything, "test-group", "test description").Return(nil, &client.APIError{StatusCode: 409})
+	mockClient.On("FindGroupsByName", mock.Anything, "test-group").Return([]client.Group{
+		{ID: "existing-id", Name: "test-group", Description: "another description"},
+	}, nil)
+
+	resp := testGroupCreate(t, mockClient, types.BoolNull(), false)
+
+	errs := resp.Diagnostics.Errors()
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "Group Name Already In Use", errs[0].Summary())
+	assert.Equal(t, path.Root("name"), errs[0].(diag.DiagnosticWithPath).Path())
+	assert.Contains(t, errs[0].Detail(), "terraform import <resource address> existing-id")
+}
+
+func TestGroupResource_Create_ConflictWithoutMatch(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("CreateGroup", mock.Anything, "test-group", "test description").Return(nil, &client.APIError{StatusCode: 409})
+	mockClient.On("FindGroupsByName", mock.Anything, "test-group").Return([]client.Group{}, nil)
+
+	resp := testGroupCreate(t, mockClient, types.BoolNull(), false)
+
+	assert.Equal(t, "Group Conflict", resp.Diagnostics.Errors()[0].Summary())
+}
+
+// testGroupModifyPlan runs ModifyPlan for the given prior state (nil when
+// creating) and planned group
+func testGroupModifyPlan(t *testing.T, mockClient *MockClient, state *GroupResourceModel, plan GroupResourceModel) *resource.ModifyPlanResponse {
+	r := &GroupResource{}
+
+	configResp := &resource.ConfigureResponse{}
+	r.Configure(context.Background(), resource.ConfigureRequest{
+		ProviderData: &ProviderData{Client: mockClient},
+	}, configResp)
+	assert.False(t, configResp.Diagnostics.HasError())
+
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	req := resource.ModifyPlanRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+	}
+	_ = req.Plan.Set(ctx, &plan)
+	if state != nil {
+		_ = req.State.Set(ctx, state)
+	}
+
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+	r.ModifyPlan(ctx, req, resp)
+
+	return resp
+}
+
+func TestGroupResource_ModifyPlan(t *testing.T) {
+	planned := GroupResourceModel{
+		ID:          types.StringUnknown(),
+		Name:        types.StringValue("test-group"),
+		Description: types.StringValue("test description"),
+		TenantID:    types.StringUnknown(),
+	}
+	existing := GroupResourceModel{
+		ID:          types.StringValue("test-id"),
+		Name:        types.StringValue("old-group"),
+		Description: types.StringValue("test description"),
+		TenantID:    types.StringNull(),
+	}
+
+	t.Run("Create with a free name", func(t *testing.T) {
+		mockClient := &MockClient{}
+		mockClient.On("FindGroupsByName", mock.Anything, "test-group").Return([]client.Group{}, nil)
+
+		resp := testGroupModifyPlan(t, mockClient, nil, planned)
+
+		assert.False(t, resp.Diagnostics.HasError())
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Create with a taken name", func(t *testing.T) {
+		mockClient := &MockClient{}
+		mockClient.On("FindGroupsByName", mock.Anything, "test-group").Return([]client.Group{
+			{ID: "other-id", Name: "test-group"},
+		}, nil)
+
+		resp := testGroupModifyPlan(t, mockClient, nil, planned)
+
+		assert.True(t, resp.Diagnostics.HasError())
+		assert.Equal(t, "Group Name Already In Use", resp.Diagnostics.Errors()[0].Summary())
+		assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "other-id")
+	})
+
+	t.Run("Rename to a taken name", func(t *testing.T) {
+		mockClient := &MockClient{}
+		mockClient.On("FindGroupsByName", mock.Anything, "test-group").Return([]client.Group{
+			{ID: "other-id", Name: "test-group"},
+		}, nil)
+
+		plan := planned
+		plan.ID = existing.ID
+		resp := testGroupModifyPlan(t, mockClient, &existing, plan)
+
+		assert.True(t, resp.Diagnostics.HasError())
+	})
+
+	t.Run("Update without rename", func(t *testing.T) {
+		mockClient := &MockClient{}
+
+		plan := existing
+		plan.Description = types.StringValue("new description")
+		resp := testGroupModifyPlan(t, mockClient, &existing, plan)
+
+		assert.False(t, resp.Diagnostics.HasError())
+		mockClient.AssertNotCalled(t, "FindGroupsByName", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Lookup failure", func(t *testing.T) {
+		mockClient := &MockClient{}
+		mockClient.On("FindGroupsByName", mock.Anything, "test-group").Return(nil, assert.AnError)
+
+		resp := testGroupModifyPlan(t, mockClient, nil, planned)
+
+		assert.False(t, resp.Diagnostics.HasError())
+		assert.Equal(t, "Could Not Check Group Name", resp.Diagnostics.Warnings()[0].Summary())
+	})
+}
+
+func TestGroupResource_Create_AdoptExisting(t *testing.T) {
+	t.Run("Adopts and updates the description", func(t *testing.T) {
+		mockClient := &MockClient{}
+		mockClient.On("FindGroupsByName", mock.Anything, "test-group").Return([]client.Group{
+			{ID: "existing-id", Name: "test-group", Description: "hand-made"},
+		}, nil)
+		mockClient.On("GetGroup", mock.Anything, "existing-id").Return(&client.Group{
+			ID: "existing-id", Name: "test-group", Description: "hand-made", ETag: `"v1"`,
+		}, nil)
+		// Only the description is sent, and only if the group is unchanged since it was read
+		mockClient.On("PatchGroup", mock.Anything, "existing-id", client.GroupPatch{Description: stringPointer("test description")}, `"v1"`).Return(&client.Group{
+			ID: "existing-id", Name: "test-group", Description: "test description", ETag: `"v2"`,
+		}, nil)
+
+		resp := testGroupCreate(t, mockClient, types.BoolValue(true), false)
+
+		assert.False(t, resp.Diagnostics.HasError())
+		assert.Equal(t, "Adopted Existing Group", resp.Diagnostics.Warnings()[0].Summary())
+		assert.Contains(t, resp.Diagnostics.Warnings()[0].Detail(), "existing-id")
+		mockClient.AssertExpectations(t)
+		mockClient.AssertNotCalled(t, "CreateGroup", mock.Anything, mock.Anything, mock.Anything)
+		mockClient.AssertNotCalled(t, "UpdateGroup", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+		var state GroupResourceModel
+		_ = resp.State.Get(context.Background(), &state)
+		assert.Equal(t, "existing-id", state.ID.ValueString())
+		assert.Equal(t, "test description", state.Description.ValueString())
+		assert.Equal(t, `"v2"`, state.ETag.ValueString())
+	})
+
+	t.Run("Changed while adopting", func(t *testing.T) {
+		mockClient := &MockClient{}
+		mockClient.On("FindGroupsByName", mock.Anything, "test-group").Return([]client.Group{
+			{ID: "existing-id", Name: "test-group", Description: "hand-made"},
+		}, nil)
+		mockClient.On("GetGroup", mock.Anything, "existing-id").Return(&client.Group{
+			ID: "existing-id", Name: "test-group", Description: "hand-made", ETag: `"v1"`,
+		}, nil)
+		mockClient.On("PatchGroup", mock.Anything, "existing-id", mock.Anything, `"v1"`).Return(nil, &client.APIError{StatusCode: 412})
+
+		resp := testGroupCreate(t, mockClient, types.BoolValue(true), false)
+
+		assert.True(t, resp.Diagnostics.HasError())
+		assert.Equal(t, "Group Changed Outside Terraform", resp.Diagnostics.Errors()[0].Summary())
+	})
+
+	t.Run("Provider default", func(t *testing.T) {
+		mockClient := &MockClient{}
+		mockClient.On("FindGroupsByName", mock.Anything, "test-group").Return([]client.Group{
+			{ID: "existing-id", Name: "test-group", Description: "test description"},
+		}, nil)
+		mockClient.On("GetGroup", mock.Anything, "existing-id").Return(&client.Group{
+			ID: "existing-id", Name: "test-group", Description: "test description",
+		}, nil)
+
+		resp := testGroupCreate(t, mockClient, types.BoolNull(), true)
+
+		assert.False(t, resp.Diagnostics.HasError())
+		mockClient.AssertNotCalled(t, "UpdateGroup", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockClient.AssertNotCalled(t, "CreateGroup", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Attribute overrides the provider default", func(t *testing.T) {
+		mockClient := &MockClient{}
+		mockClient.On("CreateGroup", mock.Anything, "test-group", "test description").Return(&client.Group{
+			ID: "test-id", Name: "test-group", Description: "test description",
+		}, nil)
+
+		resp := testGroupCreate(t, mockClient, types.BoolValue(false), true)
+
+		assert.False(t, resp.Diagnostics.HasError())
+		mockClient.AssertNotCalled(t, "FindGroupsByName", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Creates when nothing exists", func(t *testing.T) {
+		mockClient := &MockClient{}
+		mockClient.On("FindGroupsByName", mock.Anything, "test-group").Return([]client.Group{}, nil)
+		mockClient.On("CreateGroup", mock.Anything, "test-group", "test description").Return(&client.Group{
+			ID: "test-id", Name: "test-group", Description: "test description",
+		}, nil)
+
+		resp := testGroupCreate(t, mockClient, types.BoolValue(true), false)
+
+		assert.False(t, resp.Diagnostics.HasError())
+		assert.Empty(t, resp.Diagnostics.Warnings())
+	})
+
+	t.Run("Ambiguous name", func(t *testing.T) {
+		mockClient := &MockClient{}
+		mockClient.On("FindGroupsByName", mock.Anything, "test-group").Return([]client.Group{
+			{ID: "id-1", Name: "test-group"},
+			{ID: "id-2", Name: "test-group"},
+		}, nil)
+
+		resp := testGroupCreate(t, mockClient, types.BoolValue(true), false)
+
+		assert.True(t, resp.Diagnostics.HasError())
+		assert.Equal(t, "Multiple Groups Found", resp.Diagnostics.Errors()[0].Summary())
+	})
+}
+
+func TestGroupResource_ModifyPlan_AdoptExisting(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("FindGroupsByName", mock.Anything, "test-group").Return([]client.Group{
+		{ID: "existing-id", Name: "test-group"},
+	}, nil)
+
+	resp := testGroupModifyPlan(t, mockClient, nil, GroupResourceModel{
+		ID:            types.StringUnknown(),
+		Name:          types.StringValue("test-group"),
+		Description:   types.StringValue("test description"),
+		TenantID:      types.StringUnknown(),
+		AdoptExisting: types.BoolValue(true),
+	})
+
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Existing Group Will Be Adopted", resp.Diagnostics.Warnings()[0].Summary())
+	assert.Contains(t, resp.Diagnostics.Warnings()[0].Detail(), "existing-id")
+}
+
+func TestGroupResource_Import_Forms(t *testing.T) {
+	imported := client.Group{ID: "imported-id", Name: "imported-group", Description: "imported description"}
+
+	tests := []struct {
+		name        string
+		tenantID    string
+		importID    string
+		setup       func(m *MockClient)
+		wantSummary string
+	}{
+		{
+			name:     "By name",
+			importID: "name:imported-group",
+			setup: func(m *MockClient) {
+				m.On("FindGroupsByName", mock.Anything, "imported-group").Return([]client.Group{imported}, nil)
+			},
+		},
+		{
+			name:     "By tenant and ID",
+			tenantID: "acme",
+			importID: "acme/imported-id",
+			setup: func(m *MockClient) {
+				m.On("GetGroup", mock.Anything, "imported-id").Return(&imported, nil)
+			},
+		},
+		{
+			name:     "By tenant and name",
+			tenantID: "acme",
+			importID: "acme/name:imported-group",
+			setup: func(m *MockClient) {
+				m.On("FindGroupsByName", mock.Anything, "imported-group").Return([]client.Group{imported}, nil)
+			},
+		},
+		{
+			name:     "Unknown name",
+			importID: "name:missing",
+			setup: func(m *MockClient) {
+				m.On("FindGroupsByName", mock.Anything, "missing").Return([]client.Group{}, nil)
+			},
+			wantSummary: "Resource Not Found",
+		},
+		{
+			name:     "Ambiguous name",
+			importID: "name:imported-group",
+			setup: func(m *MockClient) {
+				m.On("FindGroupsByName", mock.Anything, "imported-group").Return([]client.Group{imported, {ID: "other-id", Name: "imported-group"}}, nil)
+			},
+			wantSummary: "Multiple Groups Found",
+		},
+		{
+			name:        "Other tenant",
+			tenantID:    "acme",
+			importID:    "globex/imported-id",
+			setup:       func(m *MockClient) {},
+			wantSummary: "Tenant Mismatch",
+		},
+		{
+			name:        "Malformed composite ID",
+			tenantID:    "acme",
+			importID:    "acme/imported-id/extra",
+			setup:       func(m *MockClient) {},
+			wantSummary: "Invalid Import ID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockClient{tenantID: tt.tenantID}
+			tt.setup(mockClient)
+
+			r := &GroupResource{}
+			configResp := &resource.ConfigureResponse{}
+			r.Configure(context.Background(), resource.ConfigureRequest{
+				ProviderData: &ProviderData{Client: mockClient},
+			}, configResp)
+
+			ctx := context.Background()
+
+			schemaResp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+			resp := &resource.ImportStateResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema},
+			}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: tt.importID}, resp)
+
+			if tt.wantSummary != "" {
+				assert.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantSummary, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+
+			assert.False(t, resp.Diagnostics.HasError())
+
+			var state GroupResourceModel
+			_ = resp.State.Get(ctx, &state)
+			assert.Equal(t, "imported-id", state.ID.ValueString())
+			assert.Equal(t, "imported-group", state.Name.ValueString())
+			assert.Equal(t, tt.tenantID, state.TenantID.ValueString())
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/extenda/terraform-provider-hiiretail-iam/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &GroupsDataSource{}
+
+func NewGroupsDataSource() datasource.DataSource {
+	return &GroupsDataSource{}
+}
+
+// GroupsDataSource defines the implementation of the hiiretail_iam_groups data
+// source. It lists all IAM groups, optionally filtered by a name prefix and a
+// name regular expression.
+type GroupsDataSource struct {
+	client client.IClient
+}
+
+// GroupsDataSourceModel describes the data source data model for a group listing.
+type GroupsDataSourceModel struct {
+	NamePrefix types.String           `tfsdk:"name_prefix"`
+	NameRegex  types.String           `tfsdk:"name_regex"`
+	IDs        types.List             `tfsdk:"ids"`
+	Names      types.List             `tfsdk:"names"`
+	Groups     []GroupDataSourceModel `tfsdk:"groups"`
+}
+
+func (d *GroupsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_groups"
+}
+
+func (d *GroupsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists IAM groups in HiiRetail, optionally filtered by name. When both filters are set, a group must match both.",
+		Attributes: map[string]schema.Attribute{
+			"name_prefix": schema.StringAttribute{
+				Description: "Only return groups whose name starts with this prefix.",
+				Optional:    true,
+			},
+			"name_regex": schema.StringAttribute{
+				Description: "Only return groups whose name matches this regular expression.",
+				Optional:    true,
+				Validators: []validator.String{
+					regexValidator{},
+				},
+			},
+			"ids": schema.ListAttribute{
+				Description: "The IDs of the matching groups.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"names": schema.ListAttribute{
+				Description: "The names of the matching groups.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"groups": schema.ListNestedAttribute{
+				Description: "The matching groups.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The group identifier.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the group.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "The description of the group.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *GroupsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *GroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data GroupsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Regular Expression", err.Error())
+			return
+		}
+	}
+
+	// List all groups, following pagination
+	groups, err := d.client.ListGroups(ctx, client.ListGroupsOptions{})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Failed to List Groups", "Could not list IAM groups.",
+			"This might be due to insufficient permissions or network issues.", err)
+		return
+	}
+
+	ids := []string{}
+	names := []string{}
+	data.Groups = []GroupDataSourceModel{}
+	for _, group := range groups {
+		if !strings.HasPrefix(group.Name, data.NamePrefix.ValueString()) {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(group.Name) {
+			continue
+		}
+
+		ids = append(ids, group.ID)
+		names = append(names, group.Name)
+		data.Groups = append(data.Groups, GroupDataSourceModel{
+			ID:          types.StringValue(group.ID),
+			Name:        types.StringValue(group.Name),
+			Description: types.StringValue(group.Description),
+		})
+	}
+
+	idList, listDiags := types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(listDiags...)
+	nameList, listDiags := types.ListValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(listDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.IDs = idList
+	data.Names = nameList
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/extenda/terraform-provider-hiiretail-iam/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGroupsDataSource_Read(t *testing.T) {
+	tests := []struct {
+		name       string
+		namePrefix interface{}
+		nameRegex  interface{}
+		wantIDs    []string
+	}{
+		{
+			name:    "No filters",
+			wantIDs: []string{"id-1", "id-2", "id-3"},
+		},
+		{
+			name:       "Name prefix",
+			namePrefix: "store-",
+			wantIDs:    []string{"id-1", "id-2"},
+		},
+		{
+			name:      "Name regex",
+			nameRegex: "-(admins|leads)$",
+			wantIDs:   []string{"id-2", "id-3"},
+		},
+		{
+			name:       "Prefix and regex",
+			namePrefix: "store-",
+			nameRegex:  "admins$",
+			wantIDs:    []string{"id-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockClient := &MockClient{}
+			mockClient.On("ListGroups", mock.Anything, client.ListGroupsOptions{}).Return([]client.Group{
+				{ID: "id-1", Name: "store-staff", Description: "Store staff"},
+				{ID: "id-2", Name: "store-admins", Description: "Store admins"},
+				{ID: "id-3", Name: "hq-leads", Description: "HQ leads"},
+			}, nil)
+
+			d := &GroupsDataSource{}
+			configResp := &datasource.ConfigureResponse{}
+			d.Configure(ctx, datasource.ConfigureRequest{ProviderData: &ProviderData{Client: mockClient}}, configResp)
+			assert.False(t, configResp.Diagnostics.HasError())
+
+			schemaResp := &datasource.SchemaResponse{}
+			d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+			objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+			config := tfsdk.Config{
+				Schema: schemaResp.Schema,
+				Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+					"name_prefix": tftypes.NewValue(tftypes.String, tt.namePrefix),
+					"name_regex":  tftypes.NewValue(tftypes.String, tt.nameRegex),
+					"ids":         tftypes.NewValue(objectType.AttributeTypes["ids"], nil),
+					"names":       tftypes.NewValue(objectType.AttributeTypes["names"], nil),
+					"groups":      tftypes.NewValue(objectType.AttributeTypes["groups"], nil),
+				}),
+			}
+
+			resp := &datasource.ReadResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema},
+			}
+			d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+
+			assert.False(t, resp.Diagnostics.HasError())
+
+			var state GroupsDataSourceModel
+			_ = resp.State.Get(ctx, &state)
+
+			var ids []string
+			_ = state.IDs.ElementsAs(ctx, &ids, false)
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Len(t, state.Groups, len(tt.wantIDs))
+			for i, group := range state.Groups {
+				assert.Equal(t, types.StringValue(tt.wantIDs[i]), group.ID)
+			}
+		})
+	}
+}
+
+func TestGroupsDataSource_Read_ServiceUnavailable(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockClient{}
+	mockClient.On("ListGroups", mock.Anything, client.ListGroupsOptions{}).Return(nil, fmt.Errorf("list groups: %w", client.ErrServiceUnavailable))
+
+	d := &GroupsDataSource{}
+	configResp := &datasource.ConfigureResponse{}
+	d.Configure(ctx, datasource.ConfigureRequest{ProviderData: &ProviderData{Client: mockClient}}, configResp)
+	assert.False(t, configResp.Diagnostics.HasError())
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"name_prefix": tftypes.NewValue(tftypes.String, nil),
+			"name_regex":  tftypes.NewValue(tftypes.String, nil),
+			"ids":         tftypes.NewValue(objectType.AttributeTypes["ids"], nil),
+			"names":       tftypes.NewValue(objectType.AttributeTypes["names"], nil),
+			"groups":      tftypes.NewValue(objectType.AttributeTypes["groups"], nil),
+		}),
+	}
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "IAM API Unavailable", resp.Diagnostics.Errors()[0].Summary())
+}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+// compositeIDSeparator joins the parts of a composite resource ID
+const compositeIDSeparator = "/"
+
+// compositeID joins the given parts into a composite resource ID,
+// e.g. "groupID/roleID"
+func compositeID(parts ...string) string {
+	return strings.Join(parts, compositeIDSeparator)
+}
+
+// splitCompositeID splits a composite resource ID into exactly one part per
+// name. The names are only used to describe the expected format in errors.
+func splitCompositeID(id string, names ...string) ([]string, error) {
+	parts := strings.Split(id, compositeIDSeparator)
+	if len(parts) != len(names) {
+		return nil, fmt.Errorf("expected an ID of the form %q, got %q", strings.Join(names, compositeIDSeparator), id)
+	}
+
+	for i, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("expected an ID of the form %q, but %s is empty in %q", strings.Join(names, compositeIDSeparator), names[i], id)
+		}
+	}
+
+	return parts, nil
+}
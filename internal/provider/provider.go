@@ -2,17 +2,25 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/extenda/terraform-provider-hiiretail-iam/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 )
 
 // Ensure the implementation satisfies the expected interfaces
@@ -33,6 +41,38 @@ type HiiRetailProviderModel struct {
 	OpenAPISchema types.String `tfsdk:"openapi_schema"`
 	Environment   types.String `tfsdk:"environment"`
 	BaseURL      types.String `tfsdk:"base_url"`
+	ClientID     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	TokenURL     types.String `tfsdk:"token_url"`
+	Scopes       types.List   `tfsdk:"scopes"`
+	TenantID     types.String `tfsdk:"tenant_id"`
+	Environments map[string]EnvironmentModel `tfsdk:"environments"`
+	DiscoverEndpoints types.Bool `tfsdk:"discover_endpoints"`
+	AdoptExistingGroups types.Bool `tfsdk:"adopt_existing_groups"`
+	DefaultTimeouts *TimeoutsModel `tfsdk:"default_timeouts"`
+	MaxRetries          types.Int64   `tfsdk:"max_retries"`
+	RetryMinInterval    types.String  `tfsdk:"retry_min_interval"`
+	RetryMaxInterval    types.String  `tfsdk:"retry_max_interval"`
+	RetryMaxElapsedTime types.String  `tfsdk:"retry_max_elapsed_time"`
+	MaxRequestsPerSecond types.Float64 `tfsdk:"max_requests_per_second"`
+	SensitiveLogFields   types.List    `tfsdk:"sensitive_log_fields"`
+}
+
+// ProviderData is handed to resources and data sources when they are
+// configured. It carries the API client and provider-level defaults.
+type ProviderData struct {
+	Client client.IClient
+	// AdoptExistingGroups is the default for the adopt_existing attribute of groups
+	AdoptExistingGroups bool
+	// Timeouts are the defaults for the timeouts block of resources
+	Timeouts client.TimeoutConfig
+}
+
+// EnvironmentModel describes the endpoints of a user-defined environment.
+type EnvironmentModel struct {
+	BaseURL   types.String `tfsdk:"base_url"`
+	TokenURL  types.String `tfsdk:"token_url"`
+	SchemaURL types.String `tfsdk:"schema_url"`
 }
 
 func New(version string) func() provider.Provider {
@@ -56,19 +96,132 @@ func (p *HiiRetailProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 			"openapi_schema": schema.StringAttribute{
 				Optional:    true,
 				Sensitive:  false,
-				Description: "OpenAPI schema URL for the HiiRetail IAM API",
+				Description: "OpenAPI schema URL for the HiiRetail IAM API. Only used to locate the API description; requests are always sent to the API base URL.",
 			},
 			"environment": schema.StringAttribute{
 				Optional:    true,
-				Description: "The HiiRetail environment to use. Valid values are 'test' or 'live'. Defaults to 'live'.",
+				Description: "The HiiRetail environment to use. Valid values are 'test', 'live', or any name defined in environments. Defaults to 'live'.",
 				Validators: []validator.String{
-					stringvalidator.OneOf("test", "live"),
+					environmentNameValidator{},
 				},
 			},
 			"base_url": schema.StringAttribute{
 				Optional:    true,
 				Description: "Override the API endpoint URL. If specified, takes precedence over environment.",
 			},
+			"client_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "OAuth2 client ID used to obtain access tokens. Can also be set with the HIIRETAIL_CLIENT_ID environment variable.",
+			},
+			"client_secret": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "OAuth2 client secret used to obtain access tokens. Can also be set with the HIIRETAIL_CLIENT_SECRET environment variable.",
+			},
+			"token_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "OAuth2 token endpoint URL. Can also be set with the HIIRETAIL_TOKEN_URL environment variable. Defaults to the token_url of the selected environment. Required for client credentials.",
+			},
+			"scopes": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "OAuth2 scopes to request with the access token. Can also be set with the HIIRETAIL_SCOPES environment variable as a comma- or space-separated list.",
+			},
+			"tenant_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The HiiRetail tenant to manage. Sent with every API request and recorded in the state of each resource. Can also be set with the HIIRETAIL_TENANT_ID environment variable.",
+			},
+			"discover_endpoints": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Fetch the OpenAPI schema on configure, check that the API version is supported, and send requests to the first server it lists. base_url still takes precedence. The schema is cached on disk in HIIRETAIL_CACHE_DIR or the user cache directory. Defaults to false.",
+			},
+			"adopt_existing_groups": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Default for the adopt_existing attribute of groups. When true, creating a group whose name is already taken adopts the existing group instead of failing. Defaults to false.",
+			},
+			"default_timeouts": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Default operation timeouts for resources that do not set a timeouts block, as durations such as \"30s\" or \"10m\".",
+				Attributes: map[string]schema.Attribute{
+					"create": schema.StringAttribute{
+						Optional:    true,
+						Description: "Default create timeout. Defaults to " + client.DefaultTimeoutConfig.Create.String() + ".",
+						Validators:  []validator.String{durationValidator{}},
+					},
+					"read": schema.StringAttribute{
+						Optional:    true,
+						Description: "Default read timeout. Defaults to " + client.DefaultTimeoutConfig.Read.String() + ".",
+						Validators:  []validator.String{durationValidator{}},
+					},
+					"update": schema.StringAttribute{
+						Optional:    true,
+						Description: "Default update timeout. Defaults to " + client.DefaultTimeoutConfig.Update.String() + ".",
+						Validators:  []validator.String{durationValidator{}},
+					},
+					"delete": schema.StringAttribute{
+						Optional:    true,
+						Description: "Default delete timeout. Defaults to " + client.DefaultTimeoutConfig.Delete.String() + ".",
+						Validators:  []validator.String{durationValidator{}},
+					},
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Maximum number of times a failed API request is retried. Defaults to %d.", client.DefaultRetryConfig.MaxRetries),
+				Validators: []validator.Int64{
+					int64validator.Between(0, 20),
+				},
+			},
+			"retry_min_interval": schema.StringAttribute{
+				Optional:    true,
+				Description: "Delay before the first retry, as a duration such as \"500ms\". Later retries back off exponentially. Defaults to " + client.DefaultRetryConfig.InitialInterval.String() + ".",
+				Validators:  []validator.String{durationValidator{}},
+			},
+			"retry_max_interval": schema.StringAttribute{
+				Optional:    true,
+				Description: "Longest delay between two retries, as a duration such as \"30s\". Defaults to " + client.DefaultRetryConfig.MaxInterval.String() + ".",
+				Validators:  []validator.String{durationValidator{}},
+			},
+			"retry_max_elapsed_time": schema.StringAttribute{
+				Optional:    true,
+				Description: "Total time a request may spend retrying, as a duration such as \"5m\". Defaults to " + client.DefaultRetryConfig.MaxElapsedTime.String() + ".",
+				Validators:  []validator.String{durationValidator{}},
+			},
+			"max_requests_per_second": schema.Float64Attribute{
+				Optional:    true,
+				Description: "Maximum number of API requests per second, shared by all resources and data sources. Defaults to no limit.",
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"sensitive_log_fields": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Additional log field and request body keys whose values are masked in provider logs. Access tokens, client secrets and passwords are always masked.",
+			},
+			"environments": schema.MapNestedAttribute{
+				Optional:    true,
+				Description: "Additional environments, keyed by name, that can be selected with environment. An entry named 'test' or 'live' overrides the built-in endpoints; URLs left unset are inherited from the built-in entry.",
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(environmentNameValidator{}),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"base_url": schema.StringAttribute{
+							Optional:    true,
+							Description: "API base URL of the environment. Required for environments that are not built in.",
+						},
+						"token_url": schema.StringAttribute{
+							Optional:    true,
+							Description: "OAuth2 token endpoint of the environment.",
+						},
+						"schema_url": schema.StringAttribute{
+							Optional:    true,
+							Description: "OpenAPI schema URL of the environment.",
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -88,10 +241,21 @@ func (p *HiiRetailProvider) Configure(ctx context.Context, req provider.Configur
 		config.BaseURL.ValueString(),
 	)
 
-	// Use the resolver to determine the API URL
-	apiURL := config.OpenAPISchema.ValueString()
-	if apiURL == "" {
-		apiURL = resolver.ResolveURL()
+	// Register user-defined environments before validating the selection
+	for name, env := range config.Environments {
+		err := resolver.AddEnvironment(name, Endpoints{
+			APIBaseURL: env.BaseURL.ValueString(),
+			TokenURL:   env.TokenURL.ValueString(),
+			SchemaURL:  env.SchemaURL.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("environments").AtMapKey(name),
+				"Invalid Environment Configuration",
+				err.Error(),
+			)
+			return
+		}
 	}
 
 	// Validate environment if specified
@@ -106,32 +270,250 @@ func (p *HiiRetailProvider) Configure(ctx context.Context, req provider.Configur
 		}
 	}
 
-	// Get the API token from the environment
-	token := os.Getenv("HIIRETAIL_TOKEN")
-	if token == "" {
+	// Use the resolver to determine the endpoints. The OpenAPI schema URL
+	// only locates the API description; requests always go to the API base URL.
+	endpoints := resolver.Resolve()
+	if schemaURL := config.OpenAPISchema.ValueString(); schemaURL != "" {
+		if u, err := url.Parse(schemaURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("openapi_schema"),
+				"Invalid OpenAPI Schema URL",
+				fmt.Sprintf("openapi_schema must be an absolute http(s) URL, got %q.", schemaURL),
+			)
+			return
+		}
+		endpoints.SchemaURL = schemaURL
+	}
+
+	// Optionally discover the API base URL from the OpenAPI schema
+	if config.DiscoverEndpoints.ValueBool() {
+		if endpoints.SchemaURL == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("discover_endpoints"),
+				"Endpoint Discovery Failed",
+				"No OpenAPI schema URL is known for the selected environment. Set openapi_schema or the environment's schema_url.",
+			)
+			return
+		}
+
+		doc, err := client.NewSchemaFetcher(schemaCacheDir(), nil).Fetch(ctx, endpoints.SchemaURL)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("discover_endpoints"),
+				"Endpoint Discovery Failed",
+				fmt.Sprintf("Could not fetch the OpenAPI schema from %s. Original error: %s", endpoints.SchemaURL, err),
+			)
+			return
+		}
+
+		if err := doc.CheckVersion(); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("discover_endpoints"),
+				"Unsupported API Version",
+				fmt.Sprintf("The IAM API described by %s is not compatible with this provider version: %s. Upgrade the provider or select a compatible environment.", endpoints.SchemaURL, err),
+			)
+			return
+		}
+
+		if config.BaseURL.ValueString() == "" {
+			serverURL, err := doc.ServerURL(endpoints.SchemaURL)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("discover_endpoints"),
+					"Endpoint Discovery Failed",
+					err.Error(),
+				)
+				return
+			}
+			endpoints.APIBaseURL = serverURL
+		}
+	}
+	apiURL := endpoints.APIBaseURL
+
+	// Prefer OAuth2 client credentials, falling back to a static token
+	clientID := stringValueOrEnv(config.ClientID, "HIIRETAIL_CLIENT_ID")
+	clientSecret := stringValueOrEnv(config.ClientSecret, "HIIRETAIL_CLIENT_SECRET")
+
+	retryConfig, diags := retryConfig(config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var sensitiveLogFields []string
+	if !config.SensitiveLogFields.IsNull() {
+		resp.Diagnostics.Append(config.SensitiveLogFields.ElementsAs(ctx, &sensitiveLogFields, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	opts := []client.Option{
+		client.WithRetryConfig(retryConfig),
+		client.WithRateLimit(config.MaxRequestsPerSecond.ValueFloat64()),
+		client.WithSensitiveLogFields(sensitiveLogFields...),
+		client.WithSensitiveLogValues(clientSecret, os.Getenv("HIIRETAIL_TOKEN")),
+	}
+	if tenantID := stringValueOrEnv(config.TenantID, "HIIRETAIL_TENANT_ID"); tenantID != "" {
+		opts = append(opts, client.WithTenantID(tenantID))
+	}
+
+	var c client.IClient
+	switch {
+	case clientID != "" && clientSecret != "":
+		scopes := splitScopes(os.Getenv("HIIRETAIL_SCOPES"))
+		if !config.Scopes.IsNull() {
+			scopes = nil
+			resp.Diagnostics.Append(config.Scopes.ElementsAs(ctx, &scopes, false)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		tokenURL := stringValueOrEnv(config.TokenURL, "HIIRETAIL_TOKEN_URL")
+		if tokenURL == "" {
+			tokenURL = endpoints.TokenURL
+		}
+		if tokenURL == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("token_url"),
+				"Missing Token URL",
+				"Authenticating with client_id and client_secret requires the OAuth2 token endpoint. Set token_url, the HIIRETAIL_TOKEN_URL environment variable, or token_url in the selected environment.",
+			)
+			return
+		}
+
+		tokens := client.NewClientCredentialsTokenSource(client.ClientCredentialsConfig{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			TokenURL:     tokenURL,
+			Scopes:       scopes,
+		}, nil)
+		c = client.NewClient(apiURL, "", append(opts, client.WithTokenSource(tokens))...)
+	case clientID != "" || clientSecret != "":
 		resp.Diagnostics.AddAttributeError(
-			path.Root("openapi_schema"),
-			"Missing API Token Configuration",
-			"The HIIRETAIL_TOKEN environment variable must be set.",
+			path.Root("client_id"),
+			"Incomplete Client Credentials",
+			"Both client_id and client_secret must be set to authenticate with OAuth2 client credentials.",
 		)
 		return
+	default:
+		token := os.Getenv("HIIRETAIL_TOKEN")
+		if token == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("client_id"),
+				"Missing API Credentials",
+				"Either client_id and client_secret must be configured, or the HIIRETAIL_TOKEN environment variable must be set.",
+			)
+			return
+		}
+		c = client.NewClient(apiURL, token, opts...)
 	}
 
-	// Initialize a new HiiRetail client using the configuration
-	var c client.IClient = client.NewClient(apiURL, token)
+	timeouts, err := config.DefaultTimeouts.timeoutsConfig(client.DefaultTimeoutConfig)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_timeouts"),
+			"Invalid Default Timeouts",
+			err.Error(),
+		)
+		return
+	}
 
-	resp.DataSourceData = c
-	resp.ResourceData = c
+	data := &ProviderData{
+		Client:              c,
+		AdoptExistingGroups: config.AdoptExistingGroups.ValueBool(),
+		Timeouts:            timeouts,
+	}
+	resp.DataSourceData = data
+	resp.ResourceData = data
 }
 
 // DataSources defines the data sources implemented in the provider.
 func (p *HiiRetailProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return nil
+	return []func() datasource.DataSource{
+		NewGroupDataSource,
+		NewGroupsDataSource,
+	}
 }
 
 // Resources defines the resources implemented in the provider.
 func (p *HiiRetailProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewGroupResource,
+		NewRoleResource,
+		NewRoleBindingResource,
+		NewGroupMembersResource,
+		NewGroupMemberResource,
+	}
+}
+
+// schemaCacheDir returns the directory OpenAPI schemas are cached in, or an
+// empty string if no cache directory is available
+func schemaCacheDir() string {
+	if dir := os.Getenv("HIIRETAIL_CACHE_DIR"); dir != "" {
+		return dir
+	}
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "terraform-provider-hiiretail-iam")
+}
+
+// stringValueOrEnv returns the configured value, or the named environment
+// variable when the attribute is not set
+func stringValueOrEnv(value types.String, envVar string) string {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueString()
 	}
-}
\ No newline at end of file
+	return os.Getenv(envVar)
+}
+
+// splitScopes splits a comma- or space-separated scope list
+func splitScopes(value string) []string {
+	return strings.Fields(strings.ReplaceAll(value, ",", " "))
+}
+
+// retryConfig applies the retry attributes of the provider configuration on
+// top of DefaultRetryConfig
+func retryConfig(config HiiRetailProviderModel) (client.RetryConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	retry := client.DefaultRetryConfig
+
+	if !config.MaxRetries.IsNull() && !config.MaxRetries.IsUnknown() {
+		retry.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+
+	for _, d := range []struct {
+		name   string
+		value  types.String
+		target *time.Duration
+	}{
+		{"retry_min_interval", config.RetryMinInterval, &retry.InitialInterval},
+		{"retry_max_interval", config.RetryMaxInterval, &retry.MaxInterval},
+		{"retry_max_elapsed_time", config.RetryMaxElapsedTime, &retry.MaxElapsedTime},
+	} {
+		if d.value.IsNull() || d.value.IsUnknown() {
+			continue
+		}
+		duration, err := time.ParseDuration(d.value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root(d.name), "Invalid Retry Configuration", err.Error())
+			continue
+		}
+		*d.target = duration
+	}
+
+	if retry.InitialInterval > retry.MaxInterval {
+		diags.AddAttributeError(
+			path.Root("retry_min_interval"),
+			"Invalid Retry Configuration",
+			fmt.Sprintf("retry_min_interval (%s) must not be longer than retry_max_interval (%s).", retry.InitialInterval, retry.MaxInterval),
+		)
+	}
+
+	return retry, diags
+}
@@ -1,14 +1,24 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/extenda/terraform-provider-hiiretail-iam/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -55,21 +65,7 @@ func TestProviderConfigure_MissingToken(t *testing.T) {
 	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
 
 	// Create empty config
-	schema := tftypes.Object{
-		AttributeTypes: map[string]tftypes.Type{
-			"openapi_schema": tftypes.String,
-			"environment":   tftypes.String,
-			"base_url":      tftypes.String,
-		},
-	}
-	config := tfsdk.Config{
-		Schema: schemaResp.Schema,
-		Raw: tftypes.NewValue(schema, map[string]tftypes.Value{
-			"openapi_schema": tftypes.NewValue(tftypes.String, nil),
-			"environment":   tftypes.NewValue(tftypes.String, nil),
-			"base_url":      tftypes.NewValue(tftypes.String, nil),
-		}),
-	}
+	config := testProviderConfig(ctx, schemaResp.Schema, nil)
 
 	// Create request with empty config
 	req := provider.ConfigureRequest{
@@ -85,6 +81,119 @@ func TestProviderConfigure_MissingToken(t *testing.T) {
 	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "HIIRETAIL_TOKEN environment variable must be set")
 }
 
+func TestProviderConfigure_ClientCredentials(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      map[string]tftypes.Value
+		env         map[string]string
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name: "Client credentials from configuration",
+			config: map[string]tftypes.Value{
+				"client_id":     tftypes.NewValue(tftypes.String, "my-client"),
+				"client_secret": tftypes.NewValue(tftypes.String, "my-secret"),
+				"token_url":     tftypes.NewValue(tftypes.String, "https://auth.example.com/oauth2/token"),
+				"scopes": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+					tftypes.NewValue(tftypes.String, "iam:read"),
+				}),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Client credentials from environment",
+			env: map[string]string{
+				"HIIRETAIL_CLIENT_ID":     "my-client",
+				"HIIRETAIL_CLIENT_SECRET": "my-secret",
+				"HIIRETAIL_TOKEN_URL":     "https://auth.example.com/oauth2/token",
+				"HIIRETAIL_SCOPES":        "iam:read,iam:write",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Client credentials without token endpoint",
+			config: map[string]tftypes.Value{
+				"client_id":     tftypes.NewValue(tftypes.String, "my-client"),
+				"client_secret": tftypes.NewValue(tftypes.String, "my-secret"),
+			},
+			wantErr:     true,
+			errContains: "requires the OAuth2 token endpoint",
+		},
+		{
+			name: "Client ID without secret",
+			config: map[string]tftypes.Value{
+				"client_id": tftypes.NewValue(tftypes.String, "my-client"),
+			},
+			wantErr:     true,
+			errContains: "Both client_id and client_secret must be set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"HIIRETAIL_TOKEN", "HIIRETAIL_CLIENT_ID", "HIIRETAIL_CLIENT_SECRET", "HIIRETAIL_TOKEN_URL", "HIIRETAIL_SCOPES"} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			p := &HiiRetailProvider{}
+			ctx := context.Background()
+
+			schemaResp := &provider.SchemaResponse{}
+			p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+			req := provider.ConfigureRequest{
+				Config: testProviderConfig(ctx, schemaResp.Schema, tt.config),
+			}
+			resp := &provider.ConfigureResponse{}
+
+			p.Configure(ctx, req, resp)
+
+			if tt.wantErr {
+				assert.True(t, resp.Diagnostics.HasError())
+				assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), tt.errContains)
+			} else {
+				assert.False(t, resp.Diagnostics.HasError())
+				assert.NotNil(t, resp.ResourceData)
+			}
+		})
+	}
+}
+
+func TestProviderConfigure_TenantID(t *testing.T) {
+	t.Setenv("HIIRETAIL_TOKEN", "test-token")
+	t.Setenv("HIIRETAIL_TENANT_ID", "env-tenant")
+
+	p := &HiiRetailProvider{}
+	ctx := context.Background()
+
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	// The environment variable is used when the attribute is not set
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: testProviderConfig(ctx, schemaResp.Schema, nil),
+	}, resp)
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "env-tenant", resp.ResourceData.(*ProviderData).Client.TenantID())
+
+	// The attribute takes precedence over the environment variable
+	resp = &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: testProviderConfig(ctx, schemaResp.Schema, map[string]tftypes.Value{
+			"tenant_id": tftypes.NewValue(tftypes.String, "acme"),
+		}),
+	}, resp)
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "acme", resp.ResourceData.(*ProviderData).Client.TenantID())
+}
+
+func TestSplitScopes(t *testing.T) {
+	assert.Equal(t, []string{"a", "b", "c"}, splitScopes("a, b c"))
+	assert.Empty(t, splitScopes(""))
+}
+
 func TestProviderConfigure_ValidConfig(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -146,17 +255,7 @@ func TestProviderConfigure_ValidConfig(t *testing.T) {
 			p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
 
 			// Create config
-			schema := tftypes.Object{
-				AttributeTypes: map[string]tftypes.Type{
-					"openapi_schema": tftypes.String,
-					"environment":   tftypes.String,
-					"base_url":      tftypes.String,
-				},
-			}
-			config := tfsdk.Config{
-				Schema: schemaResp.Schema,
-				Raw:    tftypes.NewValue(schema, tt.config),
-			}
+			config := testProviderConfig(ctx, schemaResp.Schema, tt.config)
 
 			// Create request with config
 			req := provider.ConfigureRequest{
@@ -184,4 +283,407 @@ func TestProviderConfigure_ValidConfig(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+// recordingTransport answers every request with a canned response and records
+// the requested URLs, so tests can pin where the provider sends requests
+type recordingTransport struct {
+	urls []string
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.urls = append(t.urls, req.Method+" "+req.URL.String())
+
+	body := `{"id": "group-1", "name": "developers", "description": "Developers"}`
+	if req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/oauth2/token") {
+		body = `{"access_token": "test-token", "token_type": "Bearer", "expires_in": 300}`
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+// useRecordingTransport routes all default-transport traffic through a
+// recordingTransport for the duration of the test
+func useRecordingTransport(t *testing.T) *recordingTransport {
+	transport := &recordingTransport{}
+	original := http.DefaultTransport
+	http.DefaultTransport = transport
+	t.Cleanup(func() { http.DefaultTransport = original })
+	return transport
+}
+
+func TestProviderConfigure_RequestURLs(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]tftypes.Value
+		env    map[string]string
+		want   []string
+	}{
+		{
+			name: "Live environment",
+			want: []string{"GET https://iam-api.retailsvc.com/v1/groups/group-1"},
+		},
+		{
+			name: "Test environment",
+			config: map[string]tftypes.Value{
+				"environment": tftypes.NewValue(tftypes.String, "test"),
+			},
+			want: []string{"GET https://iam-api-test.retailsvc.com/v1/groups/group-1"},
+		},
+		{
+			name: "Custom base_url",
+			config: map[string]tftypes.Value{
+				"environment": tftypes.NewValue(tftypes.String, "test"),
+				"base_url":    tftypes.NewValue(tftypes.String, "https://custom-api.example.com/iam"),
+			},
+			want: []string{"GET https://custom-api.example.com/iam/groups/group-1"},
+		},
+		{
+			name: "openapi_schema is never the request host",
+			config: map[string]tftypes.Value{
+				"openapi_schema": tftypes.NewValue(tftypes.String, "https://iam-api.retailsvc.com/schemas/v1/openapi.json"),
+			},
+			want: []string{"GET https://iam-api.retailsvc.com/v1/groups/group-1"},
+		},
+		{
+			name: "User-defined environment",
+			config: map[string]tftypes.Value{
+				"environment": tftypes.NewValue(tftypes.String, "staging"),
+				"environments": testEnvironments(map[string]map[string]string{
+					"staging": {"base_url": "https://iam-api-staging.retailsvc.com/v1"},
+				}),
+			},
+			want: []string{"GET https://iam-api-staging.retailsvc.com/v1/groups/group-1"},
+		},
+		{
+			name: "User-defined token endpoint",
+			config: map[string]tftypes.Value{
+				"environment": tftypes.NewValue(tftypes.String, "eu"),
+				"environments": testEnvironments(map[string]map[string]string{
+					"eu": {
+						"base_url":  "https://iam-api.eu.retailsvc.com/v1",
+						"token_url": "https://auth.eu.retailsvc.com/oauth2/token",
+					},
+				}),
+			},
+			env: map[string]string{
+				"HIIRETAIL_CLIENT_ID":     "my-client",
+				"HIIRETAIL_CLIENT_SECRET": "my-secret",
+			},
+			want: []string{
+				"POST https://auth.eu.retailsvc.com/oauth2/token",
+				"GET https://iam-api.eu.retailsvc.com/v1/groups/group-1",
+			},
+		},
+		{
+			name: "Token endpoint from environment variable",
+			config: map[string]tftypes.Value{
+				"environment": tftypes.NewValue(tftypes.String, "test"),
+			},
+			env: map[string]string{
+				"HIIRETAIL_CLIENT_ID":     "my-client",
+				"HIIRETAIL_CLIENT_SECRET": "my-secret",
+				"HIIRETAIL_TOKEN_URL":     "https://auth.example.com/oauth2/token",
+			},
+			want: []string{
+				"POST https://auth.example.com/oauth2/token",
+				"GET https://iam-api-test.retailsvc.com/v1/groups/group-1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HIIRETAIL_TOKEN", "test-token")
+			for _, name := range []string{"HIIRETAIL_CLIENT_ID", "HIIRETAIL_CLIENT_SECRET", "HIIRETAIL_TOKEN_URL", "HIIRETAIL_SCOPES"} {
+				t.Setenv(name, tt.env[name])
+			}
+			transport := useRecordingTransport(t)
+
+			p := &HiiRetailProvider{}
+			ctx := context.Background()
+
+			schemaResp := &provider.SchemaResponse{}
+			p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+			resp := &provider.ConfigureResponse{}
+			p.Configure(ctx, provider.ConfigureRequest{
+				Config: testProviderConfig(ctx, schemaResp.Schema, tt.config),
+			}, resp)
+			assert.False(t, resp.Diagnostics.HasError())
+
+			_, err := resp.ResourceData.(*ProviderData).Client.GetGroup(ctx, "group-1")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, transport.urls)
+		})
+	}
+}
+
+func TestProviderConfigure_InvalidEnvironments(t *testing.T) {
+	t.Setenv("HIIRETAIL_TOKEN", "test-token")
+
+	p := &HiiRetailProvider{}
+	ctx := context.Background()
+
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	// An environment that is neither built in nor defined
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: testProviderConfig(ctx, schemaResp.Schema, map[string]tftypes.Value{
+			"environment": tftypes.NewValue(tftypes.String, "staging"),
+		}),
+	}, resp)
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), `invalid environment "staging"`)
+
+	// A new environment without a base URL
+	resp = &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: testProviderConfig(ctx, schemaResp.Schema, map[string]tftypes.Value{
+			"environment": tftypes.NewValue(tftypes.String, "staging"),
+			"environments": testEnvironments(map[string]map[string]string{
+				"staging": {"token_url": "https://auth.example.com/oauth2/token"},
+			}),
+		}),
+	}, resp)
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "must set base_url")
+}
+
+func TestProviderConfigure_DiscoverEndpoints(t *testing.T) {
+	t.Setenv("HIIRETAIL_TOKEN", "test-token")
+	t.Setenv("HIIRETAIL_CACHE_DIR", t.TempDir())
+
+	version := "1.2.0"
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/schemas/v1/openapi.json" {
+			fmt.Fprintf(w, `{"info": {"version": %q}, "servers": [{"url": "/discovered/v1"}]}`, version)
+			return
+		}
+		w.Write([]byte(`{"id": "group-1", "name": "developers", "description": "Developers"}`))
+	}))
+	defer srv.Close()
+
+	p := &HiiRetailProvider{}
+	ctx := context.Background()
+
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	config := testProviderConfig(ctx, schemaResp.Schema, map[string]tftypes.Value{
+		"openapi_schema":     tftypes.NewValue(tftypes.String, srv.URL+"/schemas/v1/openapi.json"),
+		"discover_endpoints": tftypes.NewValue(tftypes.Bool, true),
+	})
+
+	// Requests go to the server listed in the schema
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{Config: config}, resp)
+	assert.False(t, resp.Diagnostics.HasError())
+
+	_, err := resp.ResourceData.(*ProviderData).Client.GetGroup(ctx, "group-1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/schemas/v1/openapi.json", "/discovered/v1/groups/group-1"}, requests)
+
+	// An incompatible major version is rejected
+	version = "2.0.0"
+	resp = &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{Config: config}, resp)
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Unsupported API Version", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestProviderConfigure_InvalidOpenAPISchema(t *testing.T) {
+	t.Setenv("HIIRETAIL_TOKEN", "test-token")
+
+	p := &HiiRetailProvider{}
+	ctx := context.Background()
+
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: testProviderConfig(ctx, schemaResp.Schema, map[string]tftypes.Value{
+			"openapi_schema": tftypes.NewValue(tftypes.String, "openapi.json"),
+		}),
+	}, resp)
+
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Invalid OpenAPI Schema URL", resp.Diagnostics.Errors()[0].Summary())
+}
+
+// testEnvironments builds an environments attribute value from a map of
+// environment name to endpoint attributes
+func testEnvironments(environments map[string]map[string]string) tftypes.Value {
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"base_url":   tftypes.String,
+		"token_url":  tftypes.String,
+		"schema_url": tftypes.String,
+	}}
+
+	values := make(map[string]tftypes.Value, len(environments))
+	for name, endpoints := range environments {
+		attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+		for attr := range objectType.AttributeTypes {
+			if value, ok := endpoints[attr]; ok {
+				attributes[attr] = tftypes.NewValue(tftypes.String, value)
+			} else {
+				attributes[attr] = tftypes.NewValue(tftypes.String, nil)
+			}
+		}
+		values[name] = tftypes.NewValue(objectType, attributes)
+	}
+
+	return tftypes.NewValue(tftypes.Map{ElementType: objectType}, values)
+}
+
+// testProviderConfig builds a provider configuration from the given values,
+// setting every attribute that is not listed to null
+func testProviderConfig(ctx context.Context, s schema.Schema, values map[string]tftypes.Value) tfsdk.Config {
+	objectType := s.Type().TerraformType(ctx).(tftypes.Object)
+
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+			continue
+		}
+		attributes[name] = tftypes.NewValue(attrType, nil)
+	}
+
+	return tfsdk.Config{
+		Schema: s,
+		Raw:    tftypes.NewValue(objectType, attributes),
+	}
+}
+func TestProviderConfigure_DefaultTimeouts(t *testing.T) {
+	t.Setenv("HIIRETAIL_TOKEN", "test-token")
+
+	p := &HiiRetailProvider{}
+	ctx := context.Background()
+
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	objectType := schemaResp.Schema.Attributes["default_timeouts"].GetType().TerraformType(ctx)
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: testProviderConfig(ctx, schemaResp.Schema, map[string]tftypes.Value{
+			"default_timeouts": tftypes.NewValue(objectType, map[string]tftypes.Value{
+				"create": tftypes.NewValue(tftypes.String, nil),
+				"read":   tftypes.NewValue(tftypes.String, nil),
+				"update": tftypes.NewValue(tftypes.String, nil),
+				"delete": tftypes.NewValue(tftypes.String, "20m"),
+			}),
+		}),
+	}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	timeouts := resp.ResourceData.(*ProviderData).Timeouts
+	assert.Equal(t, 20*time.Minute, timeouts.Delete)
+	assert.Equal(t, client.DefaultTimeoutConfig.Create, timeouts.Create)
+	assert.Equal(t, client.DefaultTimeoutConfig.Read, timeouts.Read)
+}
+
+func TestProviderConfigure_RetrySettings(t *testing.T) {
+	t.Setenv("HIIRETAIL_TOKEN", "test-token")
+
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	p := &HiiRetailProvider{}
+	ctx := context.Background()
+
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: testProviderConfig(ctx, schemaResp.Schema, map[string]tftypes.Value{
+			"base_url":                tftypes.NewValue(tftypes.String, srv.URL),
+			"max_retries":             tftypes.NewValue(tftypes.Number, 1),
+			"retry_min_interval":      tftypes.NewValue(tftypes.String, "1ms"),
+			"retry_max_interval":      tftypes.NewValue(tftypes.String, "5ms"),
+			"max_requests_per_second": tftypes.NewValue(tftypes.Number, 100),
+		}),
+	}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	_, err := resp.ResourceData.(*ProviderData).Client.GetGroup(ctx, "group-1")
+	assert.Error(t, err)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestProviderConfigure_InvalidRetrySettings(t *testing.T) {
+	t.Setenv("HIIRETAIL_TOKEN", "test-token")
+
+	p := &HiiRetailProvider{}
+	ctx := context.Background()
+
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: testProviderConfig(ctx, schemaResp.Schema, map[string]tftypes.Value{
+			"retry_min_interval": tftypes.NewValue(tftypes.String, "1m"),
+			"retry_max_interval": tftypes.NewValue(tftypes.String, "10s"),
+		}),
+	}, resp)
+
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Invalid Retry Configuration", resp.Diagnostics.Errors()[0].Summary())
+	assert.Equal(t, path.Root("retry_min_interval"), resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath).Path())
+}
+
+func TestProviderConfigure_SensitiveLogFields(t *testing.T) {
+	t.Setenv("HIIRETAIL_TOKEN", "secret-token")
+	t.Setenv("TF_LOG_PROVIDER_HIIRETAIL_CLIENT", "TRACE")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": "group-1", "name": "admins"}`))
+	}))
+	defer srv.Close()
+
+	p := &HiiRetailProvider{}
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: testProviderConfig(ctx, schemaResp.Schema, map[string]tftypes.Value{
+			"base_url": tftypes.NewValue(tftypes.String, srv.URL),
+			"sensitive_log_fields": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "description"),
+			}),
+		}),
+	}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	_, err := resp.ResourceData.(*ProviderData).Client.CreateGroup(ctx, "admins", "internal only")
+	assert.NoError(t, err)
+
+	assert.Contains(t, output.String(), "admins")
+	assert.NotContains(t, output.String(), "internal only")
+	assert.NotContains(t, output.String(), "secret-token")
+}
@@ -0,0 +1,348 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/extenda/terraform-provider-hiiretail-iam/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &RoleBindingResource{}
+var _ resource.ResourceWithImportState = &RoleBindingResource{}
+
+func NewRoleBindingResource() resource.Resource {
+	return &RoleBindingResource{}
+}
+
+// RoleBindingResource defines the implementation of the hiiretail_iam_role_binding
+// resource. It grants a role to a group on a set of business-unit resources.
+type RoleBindingResource struct {
+	client client.IClient
+	// timeouts are the provider defaults for the timeouts block
+	timeouts client.TimeoutConfig
+}
+
+// RoleBindingResourceModel describes the resource data model for a role binding.
+// The ID is the composite "groupID/roleID".
+type RoleBindingResourceModel struct {
+	ID       types.String   `tfsdk:"id"`
+	GroupID  types.String   `tfsdk:"group_id"`
+	RoleID   types.String   `tfsdk:"role_id"`
+	Bindings types.Set      `tfsdk:"bindings"`
+	TenantID types.String   `tfsdk:"tenant_id"`
+	Timeouts *TimeoutsModel `tfsdk:"timeouts"`
+}
+
+func (r *RoleBindingResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_binding"
+}
+
+func (r *RoleBindingResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Grants a role to an IAM group in HiiRetail, scoped to one or more business-unit resources.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The role binding identifier, in the form `groupID/roleID`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group_id": schema.StringAttribute{
+				Description: "The ID of the group the role is granted to. Changing this forces a new binding.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"role_id": schema.StringAttribute{
+				Description: "The ID of the granted role. Changing this forces a new binding.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"bindings": schema.SetAttribute{
+				Description: "The business-unit resources the role is granted on.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"tenant_id": schema.StringAttribute{
+				Description: "The tenant the binding belongs to, as configured on the provider when the binding was created.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}
+}
+
+func (r *RoleBindingResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+	r.timeouts = data.Timeouts
+}
+
+func (r *RoleBindingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoleBindingResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts, "create", r.timeouts)
+	defer cancel()
+
+	var bindings []string
+	resp.Diagnostics.Append(data.Bindings.ElementsAs(ctx, &bindings, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Grant the role to the group
+	binding, err := r.client.AddRoleBinding(ctx, data.GroupID.ValueString(), client.RoleBinding{
+		RoleID:   data.RoleID.ValueString(),
+		Bindings: bindings,
+	})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Failed to Create Role Binding", fmt.Sprintf("Could not grant role '%s' to group '%s'.", data.RoleID.ValueString(), data.GroupID.ValueString()),
+			"This might be due to an unknown group, role or resource.", err)
+		return
+	}
+
+	// Map response body to schema
+	resp.Diagnostics.Append(r.setModel(ctx, &data, data.GroupID.ValueString(), binding)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleBindingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoleBindingResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkTenant(data.TenantID, r.client.TenantID())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts, "read", r.timeouts)
+	defer cancel()
+
+	// Get the roles currently granted to the group
+	binding, err := r.findBinding(ctx, data.GroupID.ValueString(), data.RoleID.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Failed to Read Role Binding", fmt.Sprintf("Could not read the roles of IAM group (ID: %s).", data.GroupID.ValueString()),
+			"This might be due to insufficient permissions or network issues.", err)
+		return
+	}
+	if binding == nil {
+		// If the group or the grant no longer exists, remove it from state
+		tflog.Info(ctx, "Role binding no longer exists, removing it from state", map[string]interface{}{
+			"id": data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Map response body to schema
+	resp.Diagnostics.Append(r.setModel(ctx, &data, data.GroupID.ValueString(), binding)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleBindingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoleBindingResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkTenant(data.TenantID, r.client.TenantID())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts, "update", r.timeouts)
+	defer cancel()
+
+	var bindings []string
+	resp.Diagnostics.Append(data.Bindings.ElementsAs(ctx, &bindings, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the bindings can change in place; granting the role again replaces them.
+	// No ETag is tracked for bindings, so unlike groups and roles this is unconditional.
+	binding, err := r.client.AddRoleBinding(ctx, data.GroupID.ValueString(), client.RoleBinding{
+		RoleID:   data.RoleID.ValueString(),
+		Bindings: bindings,
+	})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Failed to Update Role Binding", fmt.Sprintf("Could not update the resources role '%s' is granted on for group '%s'.", data.RoleID.ValueString(), data.GroupID.ValueString()), "", err)
+		return
+	}
+
+	// Map response body to schema
+	resp.Diagnostics.Append(r.setModel(ctx, &data, data.GroupID.ValueString(), binding)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleBindingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoleBindingResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkTenant(data.TenantID, r.client.TenantID())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts, "delete", r.timeouts)
+	defer cancel()
+
+	// Revoke the role from the group
+	err := r.client.RemoveRoleBinding(ctx, data.GroupID.ValueString(), data.RoleID.ValueString())
+	if err != nil {
+		if client.IsResourceNotFound(err) {
+			// If the grant is already gone, that's okay
+			tflog.Info(ctx, "Role binding already deleted", map[string]interface{}{
+				"id": data.ID.ValueString(),
+			})
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Failed to Delete Role Binding", fmt.Sprintf("Could not revoke role '%s' from group '%s'.", data.RoleID.ValueString(), data.GroupID.ValueString()), "", err)
+		return
+	}
+}
+
+func (r *RoleBindingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := splitCompositeID(req.ID, "groupID", "roleID")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+	groupID, roleID := parts[0], parts[1]
+
+	binding, err := r.findBinding(ctx, groupID, roleID)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Client Error", "Unable to read role bindings during import.", "", err)
+		return
+	}
+	if binding == nil {
+		resp.Diagnostics.AddError("Resource Not Found",
+			fmt.Sprintf("Group %s has not been granted role %s", groupID, roleID))
+		return
+	}
+
+	var data RoleBindingResourceModel
+	resp.Diagnostics.Append(r.setModel(ctx, &data, groupID, binding)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set into state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findBinding returns the group's grant of the given role, or nil if the
+// group does not exist or does not hold the role
+func (r *RoleBindingResource) findBinding(ctx context.Context, groupID string, roleID string) (*client.RoleBinding, error) {
+	bindings, err := r.client.ListRoleBindings(ctx, groupID)
+	if err != nil {
+		if client.IsResourceNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for i := range bindings {
+		if bindings[i].RoleID == roleID {
+			return &bindings[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// setModel maps an API role binding onto the resource model
+func (r *RoleBindingResource) setModel(ctx context.Context, data *RoleBindingResourceModel, groupID string, binding *client.RoleBinding) diag.Diagnostics {
+	bindings, diags := types.SetValueFrom(ctx, types.StringType, binding.Bindings)
+
+	data.ID = types.StringValue(compositeID(groupID, binding.RoleID))
+	data.GroupID = types.StringValue(groupID)
+	data.RoleID = types.StringValue(binding.RoleID)
+	data.Bindings = bindings
+	data.TenantID = tenantValue(r.client.TenantID())
+
+	return diags
+}
@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/extenda/terraform-provider-hiiretail-iam/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func (m *MockClient) ListRoleBindings(ctx context.Context, groupID string) ([]client.RoleBinding, error) {
+	args := m.Called(ctx, groupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]client.RoleBinding), args.Error(1)
+}
+
+func (m *MockClient) AddRoleBinding(ctx context.Context, groupID string, binding client.RoleBinding) (*client.RoleBinding, error) {
+	args := m.Called(ctx, groupID, binding)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*client.RoleBinding), args.Error(1)
+}
+
+func (m *MockClient) RemoveRoleBinding(ctx context.Context, groupID string, roleID string) error {
+	args := m.Called(ctx, groupID, roleID)
+	return args.Error(0)
+}
+
+func TestRoleBindingResource_Create(t *testing.T) {
+	mockClient := &MockClient{}
+	r := &RoleBindingResource{}
+	s := configureTestResource(t, r, mockClient)
+	ctx := context.Background()
+
+	mockClient.On("AddRoleBinding", mock.Anything, "group-id", client.RoleBinding{
+		RoleID:   "role-id",
+		Bindings: []string{"bu:001"},
+	}).Return(&client.RoleBinding{RoleID: "role-id", Bindings: []string{"bu:001"}}, nil)
+
+	plan := tfsdk.Plan{Schema: s}
+	_ = plan.Set(ctx, &RoleBindingResourceModel{
+		ID:       types.StringUnknown(),
+		GroupID:  types.StringValue("group-id"),
+		RoleID:   types.StringValue("role-id"),
+		Bindings: testStringSet("bu:001"),
+		TenantID: types.StringUnknown(),
+	})
+
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: s},
+	}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	mockClient.AssertExpectations(t)
+
+	var actualState RoleBindingResourceModel
+	_ = resp.State.Get(ctx, &actualState)
+	assert.Equal(t, "group-id/role-id", actualState.ID.ValueString())
+}
+
+func TestRoleBindingResource_Read(t *testing.T) {
+	tests := []struct {
+		name        string
+		bindings    []client.RoleBinding
+		err         error
+		wantRemoved bool
+	}{
+		{
+			name: "Binding exists",
+			bindings: []client.RoleBinding{
+				{RoleID: "other-role", Bindings: []string{"bu:001"}},
+				{RoleID: "role-id", Bindings: []string{"bu:001", "bu:002"}},
+			},
+		},
+		{
+			name:        "Role no longer granted",
+			bindings:    []client.RoleBinding{{RoleID: "other-role", Bindings: []string{"bu:001"}}},
+			wantRemoved: true,
+		},
+		{
+			name:        "Group deleted",
+			err:         &client.ResourceNotFoundError{ResourceType: "groups", ID: "group-id"},
+			wantRemoved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			r := &RoleBindingResource{}
+			s := configureTestResource(t, r, mockClient)
+			ctx := context.Background()
+
+			mockClient.On("ListRoleBindings", mock.Anything, "group-id").Return(tt.bindings, tt.err)
+
+			state := tfsdk.State{Schema: s}
+			_ = state.Set(ctx, &RoleBindingResourceModel{
+				ID:       types.StringValue("group-id/role-id"),
+				GroupID:  types.StringValue("group-id"),
+				RoleID:   types.StringValue("role-id"),
+				Bindings: testStringSet("bu:001"),
+				TenantID: types.StringNull(),
+			})
+
+			resp := &resource.ReadResponse{State: state}
+			r.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+			assert.False(t, resp.Diagnostics.HasError())
+			if tt.wantRemoved {
+				assert.True(t, resp.State.Raw.IsNull())
+				return
+			}
+
+			var actualState RoleBindingResourceModel
+			_ = resp.State.Get(ctx, &actualState)
+			assert.Equal(t, testStringSet("bu:001", "bu:002"), actualState.Bindings)
+		})
+	}
+}
+
+func TestRoleBindingResource_Delete(t *testing.T) {
+	mockClient := &MockClient{}
+	r := &RoleBindingResource{}
+	s := configureTestResource(t, r, mockClient)
+	ctx := context.Background()
+
+	mockClient.On("RemoveRoleBinding", mock.Anything, "group-id", "role-id").Return(nil)
+
+	state := tfsdk.State{Schema: s}
+	_ = state.Set(ctx, &RoleBindingResourceModel{
+		ID:       types.StringValue("group-id/role-id"),
+		GroupID:  types.StringValue("group-id"),
+		RoleID:   types.StringValue("role-id"),
+		Bindings: testStringSet("bu:001"),
+		TenantID: types.StringNull(),
+	})
+
+	resp := &resource.DeleteResponse{}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	mockClient.AssertExpectations(t)
+}
+
+func TestRoleBindingResource_Import(t *testing.T) {
+	mockClient := &MockClient{}
+	r := &RoleBindingResource{}
+	s := configureTestResource(t, r, mockClient)
+	ctx := context.Background()
+
+	mockClient.On("ListRoleBindings", mock.Anything, "group-id").Return([]client.RoleBinding{
+		{RoleID: "role-id", Bindings: []string{"bu:001"}},
+	}, nil)
+
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{Schema: s},
+	}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "group-id/role-id"}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+
+	var actualState RoleBindingResourceModel
+	_ = resp.State.Get(ctx, &actualState)
+	assert.Equal(t, "group-id", actualState.GroupID.ValueString())
+	assert.Equal(t, "role-id", actualState.RoleID.ValueString())
+	assert.Equal(t, testStringSet("bu:001"), actualState.Bindings)
+}
+
+func TestRoleBindingResource_Import_InvalidID(t *testing.T) {
+	mockClient := &MockClient{}
+	r := &RoleBindingResource{}
+	s := configureTestResource(t, r, mockClient)
+
+	for _, id := range []string{"group-id", "group-id/", "a/b/c"} {
+		resp := &resource.ImportStateResponse{
+			State: tfsdk.State{Schema: s},
+		}
+		r.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, resp)
+
+		assert.True(t, resp.Diagnostics.HasError(), id)
+		assert.Equal(t, "Invalid Import ID", resp.Diagnostics.Errors()[0].Summary())
+	}
+}
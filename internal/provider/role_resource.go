@@ -0,0 +1,336 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/extenda/terraform-provider-hiiretail-iam/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &RoleResource{}
+var _ resource.ResourceWithImportState = &RoleResource{}
+
+func NewRoleResource() resource.Resource {
+	return &RoleResource{}
+}
+
+// RoleResource defines the implementation of the hiiretail_iam_role resource.
+// It manages custom IAM roles, which bundle a set of permissions that can
+// later be granted to groups.
+type RoleResource struct {
+	client client.IClient
+	// timeouts are the provider defaults for the timeouts block
+	timeouts client.TimeoutConfig
+}
+
+// RoleResourceModel describes the resource data model for a custom IAM role.
+type RoleResourceModel struct {
+	ID          types.String   `tfsdk:"id"`
+	Title       types.String   `tfsdk:"title"`
+	Description types.String   `tfsdk:"description"`
+	Permissions types.Set      `tfsdk:"permissions"`
+	TenantID    types.String   `tfsdk:"tenant_id"`
+	ETag        types.String   `tfsdk:"etag"`
+	Timeouts    *TimeoutsModel `tfsdk:"timeouts"`
+}
+
+func (r *RoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role"
+}
+
+func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a custom IAM role in HiiRetail. Roles bundle permissions that can be granted to groups.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The role identifier.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"title": schema.StringAttribute{
+				Description: "The title of the role. Must be between 1 and 128 characters.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 128),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "A description of the role explaining its purpose. At most 256 characters.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(256),
+				},
+			},
+			"permissions": schema.SetAttribute{
+				Description: "The permissions granted by the role, in the form <system>.<resource>.<action>, e.g. \"pos.receipt.read\".",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(permissionValidator{}),
+				},
+			},
+			"etag": schema.StringAttribute{
+				Description: "The version of the role last read from HiiRetail. Updates and deletes are rejected if the role was modified since.",
+				Computed:    true,
+			},
+			"tenant_id": schema.StringAttribute{
+				Description: "The tenant the role belongs to, as configured on the provider when the role was created.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}
+}
+
+func (r *RoleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+	r.timeouts = data.Timeouts
+}
+
+func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts, "create", r.timeouts)
+	defer cancel()
+
+	var permissions []string
+	resp.Diagnostics.Append(data.Permissions.ElementsAs(ctx, &permissions, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create new role
+	role, err := r.client.CreateRole(ctx, data.Title.ValueString(), data.Description.ValueString(), permissions)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Failed to Create Role", fmt.Sprintf("Could not create IAM role '%s'.", data.Title.ValueString()),
+			"This might be due to an unknown permission or invalid input.", err)
+		return
+	}
+
+	// Map response body to schema
+	resp.Diagnostics.Append(r.setModel(ctx, &data, role)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkTenant(data.TenantID, r.client.TenantID())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts, "read", r.timeouts)
+	defer cancel()
+
+	// Get refreshed role value from HiiRetail
+	role, err := r.client.GetRole(ctx, data.ID.ValueString())
+	if err != nil {
+		if client.IsResourceNotFound(err) {
+			// If the resource does not exist, remove it from state
+			tflog.Info(ctx, "Role no longer exists, removing it from state", map[string]interface{}{
+				"id": data.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Failed to Read Role", fmt.Sprintf("Could not read IAM role (ID: %s).", data.ID.ValueString()),
+			"This might be due to insufficient permissions or network issues.", err)
+		return
+	}
+
+	// Map response body to schema
+	resp.Diagnostics.Append(r.setModel(ctx, &data, role)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkTenant(data.TenantID, r.client.TenantID())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts, "update", r.timeouts)
+	defer cancel()
+
+	var permissions []string
+	resp.Diagnostics.Append(data.Permissions.ElementsAs(ctx, &permissions, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	etag, diags := stateETag(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update existing role, unless it changed since it was last read
+	role, err := r.client.UpdateRole(ctx, data.ID.ValueString(), data.Title.ValueString(), data.Description.ValueString(), permissions, etag)
+	if err != nil {
+		if client.IsPreconditionFailed(err) {
+			addPreconditionFailedError(&resp.Diagnostics, "Role",
+				fmt.Sprintf("Could not update IAM role '%s' (ID: %s).", data.Title.ValueString(), data.ID.ValueString()), err)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Failed to Update Role", fmt.Sprintf("Could not update IAM role '%s' (ID: %s).", data.Title.ValueString(), data.ID.ValueString()),
+			"This might be due to concurrent modifications or invalid input.", err)
+		return
+	}
+
+	// Map response body to schema
+	resp.Diagnostics.Append(r.setModel(ctx, &data, role)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkTenant(data.TenantID, r.client.TenantID())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts, "delete", r.timeouts)
+	defer cancel()
+
+	// Delete existing role, unless it changed since it was last read
+	err := r.client.DeleteRole(ctx, data.ID.ValueString(), data.ETag.ValueString())
+	if err != nil {
+		if client.IsResourceNotFound(err) {
+			// If the resource is already gone, that's okay
+			tflog.Info(ctx, "Role already deleted", map[string]interface{}{
+				"id": data.ID.ValueString(),
+			})
+			return
+		}
+		if client.IsPreconditionFailed(err) {
+			addPreconditionFailedError(&resp.Diagnostics, "Role",
+				fmt.Sprintf("Could not delete IAM role (ID: %s).", data.ID.ValueString()), err)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Failed to Delete Role", fmt.Sprintf("Could not delete IAM role (ID: %s).", data.ID.ValueString()),
+			"This might be due to the role still being granted to groups.", err)
+		return
+	}
+}
+
+func (r *RoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Read the role data directly
+	role, err := r.client.GetRole(ctx, req.ID)
+	if err != nil {
+		if client.IsResourceNotFound(err) {
+			resp.Diagnostics.AddError("Resource Not Found",
+				fmt.Sprintf("Unable to find role %s for import", req.ID))
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Client Error", "Unable to read role during import.", "", err)
+		return
+	}
+
+	var data RoleResourceModel
+	resp.Diagnostics.Append(r.setModel(ctx, &data, role)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set into state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// setModel maps an API role onto the resource model
+func (r *RoleResource) setModel(ctx context.Context, data *RoleResourceModel, role *client.Role) diag.Diagnostics {
+	permissions, diags := types.SetValueFrom(ctx, types.StringType, role.Permissions)
+
+	data.ID = types.StringValue(role.ID)
+	data.Title = types.StringValue(role.Title)
+	data.Description = types.StringValue(role.Description)
+	data.Permissions = permissions
+	data.TenantID = tenantValue(r.client.TenantID())
+	data.ETag = etagValue(role.ETag)
+
+	return diags
+}
@@ -0,0 +1,355 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/extenda/terraform-provider-hiiretail-iam/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func (m *MockClient) CreateRole(ctx context.Context, title string, description string, permissions []string) (*client.Role, error) {
+	args := m.Called(ctx, title, description, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*client.Role), args.Error(1)
+}
+
+func (m *MockClient) GetRole(ctx context.Context, id string) (*client.Role, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*client.Role), args.Error(1)
+}
+
+func (m *MockClient) UpdateRole(ctx context.Context, id string, title string, description string, permissions []string, etag string) (*client.Role, error) {
+	args := m.Called(ctx, id, title, description, permissions, etag)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*client.Role), args.Error(1)
+}
+
+func (m *MockClient) DeleteRole(ctx context.Context, id string, etag string) error {
+	args := m.Called(ctx, id, etag)
+	return args.Error(0)
+}
+
+// testStringSet builds a set of strings value for tests
+func testStringSet(values ...string) types.Set {
+	set, _ := types.SetValueFrom(context.Background(), types.StringType, values)
+	return set
+}
+
+func TestRoleResourceSchema(t *testing.T) {
+	r := &RoleResource{}
+	response := &resource.SchemaResponse{}
+
+	r.Schema(context.Background(), resource.SchemaRequest{}, response)
+
+	assert.Contains(t, response.Schema.Attributes, "id")
+	assert.True(t, response.Schema.Attributes["id"].IsComputed())
+
+	assert.Contains(t, response.Schema.Attributes, "title")
+	assert.True(t, response.Schema.Attributes["title"].IsRequired())
+
+	assert.Contains(t, response.Schema.Attributes, "description")
+	assert.True(t, response.Schema.Attributes["description"].IsOptional())
+
+	assert.Contains(t, response.Schema.Attributes, "permissions")
+	permissionsAttr := response.Schema.Attributes["permissions"].(schema.SetAttribute)
+	assert.True(t, permissionsAttr.Required)
+	assert.NotEmpty(t, permissionsAttr.Validators)
+}
+
+func TestRoleResource_Create(t *testing.T) {
+	mockClient := &MockClient{}
+	r := &RoleResource{}
+	s := configureTestResource(t, r, mockClient)
+	ctx := context.Background()
+
+	mockClient.On("CreateRole", mock.Anything, "Cashier", "Point of sale cashier", mock.MatchedBy(func(permissions []string) bool {
+		return assert.ElementsMatch(t, []string{"pos.receipt.read", "pos.receipt.create"}, permissions)
+	})).Return(&client.Role{
+		ID:          "role-id",
+		Title:       "Cashier",
+		Description: "Point of sale cashier",
+		Permissions: []string{"pos.receipt.read", "pos.receipt.create"},
+	}, nil)
+
+	plan := tfsdk.Plan{Schema: s}
+	_ = plan.Set(ctx, &RoleResourceModel{
+		ID:          types.StringUnknown(),
+		Title:       types.StringValue("Cashier"),
+		Description: types.StringValue("Point of sale cashier"),
+		Permissions: testStringSet("pos.receipt.read", "pos.receipt.create"),
+		TenantID:    types.StringUnknown(),
+	})
+
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: s},
+	}
+
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	mockClient.AssertExpectations(t)
+
+	var actualState RoleResourceModel
+	_ = resp.State.Get(ctx, &actualState)
+	assert.Equal(t, "role-id", actualState.ID.ValueString())
+	assert.Equal(t, "Cashier", actualState.Title.ValueString())
+	assert.Equal(t, testStringSet("pos.receipt.read", "pos.receipt.create"), actualState.Permissions)
+}
+
+func TestRoleResource_Read(t *testing.T) {
+	mockClient := &MockClient{}
+	r := &RoleResource{}
+	s := configureTestResource(t, r, mockClient)
+	ctx := context.Background()
+
+	mockClient.On("GetRole", mock.Anything, "role-id").Return(&client.Role{
+		ID:          "role-id",
+		Title:       "Cashier",
+		Description: "Point of sale cashier",
+		Permissions: []string{"pos.receipt.read"},
+	}, nil)
+
+	state := tfsdk.State{Schema: s}
+	_ = state.Set(ctx, &RoleResourceModel{
+		ID:          types.StringValue("role-id"),
+		Title:       types.StringValue("Old title"),
+		Description: types.StringValue(""),
+		Permissions: testStringSet("pos.receipt.read", "pos.receipt.create"),
+		TenantID:    types.StringNull(),
+	})
+
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	mockClient.AssertExpectations(t)
+
+	var actualState RoleResourceModel
+	_ = resp.State.Get(ctx, &actualState)
+	assert.Equal(t, "Cashier", actualState.Title.ValueString())
+	assert.Equal(t, testStringSet("pos.receipt.read"), actualState.Permissions)
+}
+
+func TestRoleResource_Read_NotFound(t *testing.T) {
+	mockClient := &MockClient{}
+	r := &RoleResource{}
+	s := configureTestResource(t, r, mockClient)
+	ctx := context.Background()
+
+	mockClient.On("GetRole", mock.Anything, "role-id").Return(nil, &client.ResourceNotFoundError{ResourceType: "roles", ID: "role-id"})
+
+	state := tfsdk.State{Schema: s}
+	_ = state.Set(ctx, &RoleResourceModel{
+		ID:          types.StringValue("role-id"),
+		Title:       types.StringValue("Cashier"),
+		Description: types.StringValue(""),
+		Permissions: testStringSet("pos.receipt.read"),
+		TenantID:    types.StringNull(),
+	})
+
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.True(t, resp.State.Raw.IsNull())
+}
+
+func TestRoleResource_Read_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantSummary string
+	}{
+		{"Circuit open", fmt.Errorf("get role: %w", client.ErrServiceUnavailable), "IAM API Unavailable"},
+		{"Timed out", fmt.Errorf("get role: %w", context.DeadlineExceeded), "Operation Timed Out"},
+		{"Other error", errors.New("connection reset"), "Failed to Read Role"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			r := &RoleResource{}
+			s := configureTestResource(t, r, mockClient)
+			ctx := context.Background()
+
+			mockClient.On("GetRole", mock.Anything, "role-id").Return(nil, tt.err)
+
+			state := tfsdk.State{Schema: s}
+			_ = state.Set(ctx, &RoleResourceModel{
+				ID:          types.StringValue("role-id"),
+				Title:       types.StringValue("Cashier"),
+				Description: types.StringValue(""),
+				Permissions: testStringSet("pos.receipt.read"),
+				TenantID:    types.StringNull(),
+			})
+
+			resp := &resource.ReadResponse{State: state}
+			r.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+			assert.True(t, resp.Diagnostics.HasError())
+			assert.Equal(t, tt.wantSummary, resp.Diagnostics.Errors()[0].Summary())
+			assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "role-id")
+		})
+	}
+}
+
+func TestRoleResource_Update(t *testing.T) {
+	mockClient := &MockClient{}
+	r := &RoleResource{}
+	s := configureTestResource(t, r, mockClient)
+	ctx := context.Background()
+
+	mockClient.On("UpdateRole", mock.Anything, "role-id", "Senior cashier", "", []string{"pos.receipt.refund"}, "").Return(&client.Role{
+		ID:          "role-id",
+		Title:       "Senior cashier",
+		Permissions: []string{"pos.receipt.refund"},
+	}, nil)
+
+	state := tfsdk.State{Schema: s}
+	_ = state.Set(ctx, &RoleResourceModel{
+		ID:          types.StringValue("role-id"),
+		Title:       types.StringValue("Cashier"),
+		Description: types.StringValue(""),
+		Permissions: testStringSet("pos.receipt.read"),
+		TenantID:    types.StringNull(),
+	})
+
+	plan := tfsdk.Plan{Schema: s}
+	_ = plan.Set(ctx, &RoleResourceModel{
+		ID:          types.StringValue("role-id"),
+		Title:       types.StringValue("Senior cashier"),
+		Description: types.StringValue(""),
+		Permissions: testStringSet("pos.receipt.refund"),
+		TenantID:    types.StringNull(),
+	})
+
+	resp := &resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{State: state, Plan: plan}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	mockClient.AssertExpectations(t)
+
+	var actualState RoleResourceModel
+	_ = resp.State.Get(ctx, &actualState)
+	assert.Equal(t, "Senior cashier", actualState.Title.ValueString())
+	assert.Equal(t, testStringSet("pos.receipt.refund"), actualState.Permissions)
+}
+
+func TestRoleResource_Delete(t *testing.T) {
+	mockClient := &MockClient{}
+	r := &RoleResource{}
+	s := configureTestResource(t, r, mockClient)
+	ctx := context.Background()
+
+	mockClient.On("DeleteRole", mock.Anything, "role-id", "").Return(nil)
+
+	state := tfsdk.State{Schema: s}
+	_ = state.Set(ctx, &RoleResourceModel{
+		ID:          types.StringValue("role-id"),
+		Title:       types.StringValue("Cashier"),
+		Description: types.StringValue(""),
+		Permissions: testStringSet("pos.receipt.read"),
+		TenantID:    types.StringNull(),
+	})
+
+	resp := &resource.DeleteResponse{}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	mockClient.AssertExpectations(t)
+}
+
+func TestRoleResource_Import(t *testing.T) {
+	mockClient := &MockClient{}
+	r := &RoleResource{}
+	s := configureTestResource(t, r, mockClient)
+	ctx := context.Background()
+
+	mockClient.On("GetRole", mock.Anything, "role-id").Return(&client.Role{
+		ID:          "role-id",
+		Title:       "Cashier",
+		Description: "Point of sale cashier",
+		Permissions: []string{"pos.receipt.read"},
+	}, nil)
+
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{Schema: s},
+	}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "role-id"}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	mockClient.AssertExpectations(t)
+
+	var actualState RoleResourceModel
+	_ = resp.State.Get(ctx, &actualState)
+	assert.Equal(t, "role-id", actualState.ID.ValueString())
+	assert.Equal(t, testStringSet("pos.receipt.read"), actualState.Permissions)
+}
+
+func TestPermissionValidator(t *testing.T) {
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{value: "pos.receipt.read", wantErr: false},
+		{value: "iam.group-member.create", wantErr: false},
+		{value: "pos.receipt", wantErr: true},
+		{value: "POS.receipt.read", wantErr: true},
+		{value: "pos.receipt.read.all", wantErr: true},
+		{value: "pos..read", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			permissionValidator{}.ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("permissions"),
+				ConfigValue: types.StringValue(tt.value),
+			}, resp)
+			assert.Equal(t, tt.wantErr, resp.Diagnostics.HasError())
+		})
+	}
+}
+
+func TestRoleResource_Delete_ChangedOutsideTerraform(t *testing.T) {
+	mockClient := &MockClient{}
+	r := &RoleResource{}
+	s := configureTestResource(t, r, mockClient)
+	ctx := context.Background()
+
+	mockClient.On("DeleteRole", mock.Anything, "role-id", `"v1"`).Return(&client.APIError{StatusCode: 412})
+
+	state := tfsdk.State{Schema: s}
+	_ = state.Set(ctx, &RoleResourceModel{
+		ID:          types.StringValue("role-id"),
+		Title:       types.StringValue("Cashier"),
+		Description: types.StringValue(""),
+		Permissions: testStringSet("pos.receipt.read"),
+		TenantID:    types.StringNull(),
+		ETag:        types.StringValue(`"v1"`),
+	})
+
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+
+	assert.Len(t, resp.Diagnostics.Errors(), 1)
+	assert.Equal(t, "Role Changed Outside Terraform", resp.Diagnostics.Errors()[0].Summary())
+	mockClient.AssertExpectations(t)
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// tenantValue converts the configured tenant ID into its state value.
+// An unconfigured tenant is stored as null.
+func tenantValue(tenantID string) types.String {
+	if tenantID == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(tenantID)
+}
+
+// checkTenant verifies that a resource's state was written under the tenant
+// the provider is currently configured for. State written before tenant
+// tracking was introduced has no tenant and is accepted as is.
+func checkTenant(stateTenant types.String, tenantID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if stateTenant.IsNull() || stateTenant.IsUnknown() {
+		return diags
+	}
+
+	if stateTenant.ValueString() != tenantID {
+		configured := tenantID
+		if configured == "" {
+			configured = "(none)"
+		}
+		diags.AddAttributeError(
+			path.Root("tenant_id"),
+			"Tenant Mismatch",
+			fmt.Sprintf("This resource was created in tenant %q, but the provider is configured for tenant %q. "+
+				"Refusing to manage a resource that belongs to another tenant. Check the provider's tenant_id setting "+
+				"or the HIIRETAIL_TENANT_ID environment variable.", stateTenant.ValueString(), configured),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/extenda/terraform-provider-hiiretail-iam/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TimeoutsModel describes a timeouts block, or the provider's
+// default_timeouts. Each value is a Go duration string such as "30s" or "10m".
+// A nil *TimeoutsModel stands for an omitted block.
+type TimeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+	Read   types.String `tfsdk:"read"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+// timeoutsAttributeDescriptions describes each operation timeout
+var timeoutsAttributeDescriptions = map[string]string{
+	"create": "Timeout for creating the resource, e.g. \"30s\" or \"10m\".",
+	"read":   "Timeout for reading the resource, e.g. \"30s\" or \"10m\".",
+	"update": "Timeout for updating the resource, e.g. \"30s\" or \"10m\".",
+	"delete": "Timeout for deleting the resource, e.g. \"30s\" or \"10m\".",
+}
+
+// timeoutsBlock returns the schema of the timeouts block shared by resources
+func timeoutsBlock() schema.SingleNestedBlock {
+	attributes := make(map[string]schema.Attribute, len(timeoutsAttributeDescriptions))
+	for name, description := range timeoutsAttributeDescriptions {
+		attributes[name] = schema.StringAttribute{
+			Description: description + " Defaults to the provider's default_timeouts.",
+			Optional:    true,
+			Validators: []validator.String{
+				durationValidator{},
+			},
+		}
+	}
+
+	return schema.SingleNestedBlock{
+		Description: "Timeouts for the operations on this resource.",
+		Attributes:  attributes,
+	}
+}
+
+// timeoutsConfig applies the timeouts in m on top of base
+func (m *TimeoutsModel) timeoutsConfig(base client.TimeoutConfig) (client.TimeoutConfig, error) {
+	if m == nil {
+		return base, nil
+	}
+
+	var err error
+	for _, t := range []struct {
+		value  types.String
+		target *time.Duration
+	}{
+		{m.Create, &base.Create},
+		{m.Read, &base.Read},
+		{m.Update, &base.Update},
+		{m.Delete, &base.Delete},
+	} {
+		if t.value.IsNull() || t.value.IsUnknown() {
+			continue
+		}
+		if *t.target, err = time.ParseDuration(t.value.ValueString()); err != nil {
+			return base, err
+		}
+	}
+
+	return base, nil
+}
+
+// operationTimeout returns the timeout of the named operation ("create",
+// "read", "update" or "delete") configured in m, or the default from defaults
+func (m *TimeoutsModel) operationTimeout(operation string, defaults client.TimeoutConfig) time.Duration {
+	// Values were validated with the configuration, so errors cannot occur here
+	config, err := m.timeoutsConfig(defaults)
+	if err != nil {
+		config = defaults
+	}
+
+	switch operation {
+	case "create":
+		return config.Create
+	case "read":
+		return config.Read
+	case "update":
+		return config.Update
+	case "delete":
+		return config.Delete
+	}
+
+	return config.Default
+}
+
+// withOperationTimeout bounds ctx by the timeout of the named operation. The
+// deadline is what limits the client calls made with the returned context.
+// A zero timeout leaves ctx without a deadline.
+func withOperationTimeout(ctx context.Context, m *TimeoutsModel, operation string, defaults client.TimeoutConfig) (context.Context, context.CancelFunc) {
+	timeout := m.operationTimeout(operation, defaults)
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
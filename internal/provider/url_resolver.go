@@ -2,51 +2,106 @@ package provider
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 )
 
 // URLResolver handles the resolution of API URLs based on environment settings
 type URLResolver struct {
-	environment string
-	baseURL    string
+	environment  string
+	baseURL     string
+	environments map[string]Endpoints
 }
 
 // NewURLResolver creates a new URLResolver instance
 func NewURLResolver(environment, baseURL string) *URLResolver {
+	environments := make(map[string]Endpoints, len(environmentEndpoints))
+	for name, endpoints := range environmentEndpoints {
+		environments[name] = endpoints
+	}
+
 	return &URLResolver{
-		environment: environment,
-		baseURL:    baseURL,
+		environment:  environment,
+		baseURL:     baseURL,
+		environments: environments,
 	}
 }
 
-// ResolveURL returns the appropriate URL based on configuration
-func (r *URLResolver) ResolveURL() string {
-	// Base URL takes precedence if specified
-	if r.baseURL != "" {
-		return r.baseURL
+// AddEnvironment registers a user-defined environment. Endpoints left empty
+// are inherited from the built-in environment of the same name, if any.
+func (r *URLResolver) AddEnvironment(name string, endpoints Endpoints) error {
+	base := r.environments[name]
+
+	if endpoints.APIBaseURL == "" {
+		endpoints.APIBaseURL = base.APIBaseURL
+	}
+	if endpoints.SchemaURL == "" {
+		endpoints.SchemaURL = base.SchemaURL
 	}
+	if endpoints.TokenURL == "" {
+		endpoints.TokenURL = base.TokenURL
+	}
+
+	if endpoints.APIBaseURL == "" {
+		return fmt.Errorf("environment %q must set base_url", name)
+	}
+
+	r.environments[name] = endpoints
+	return nil
+}
+
+// Endpoints is the set of URLs the provider talks to in an environment
+type Endpoints struct {
+	// APIBaseURL is the host and path prefix of every IAM API request
+	APIBaseURL string
+	// SchemaURL is where the OpenAPI document of the IAM API is published
+	SchemaURL string
+	// TokenURL is the OAuth2 token endpoint used for client credentials
+	TokenURL string
+}
+
+// environmentEndpoints maps environment names to their endpoints. The schema
+// URLs are the ones the provider has always used, and the live API base URL
+// is the endpoint documented in the README; the test API is assumed to share
+// its path on the test host. No token endpoint is published, so client
+// credentials need token_url to be configured.
+var environmentEndpoints = map[string]Endpoints{
+	"test": {
+		APIBaseURL: "https://iam-api-test.retailsvc.com/v1",
+		SchemaURL:  "https://iam-api-test.retailsvc.com/schemas/v1/openapi.json",
+	},
+	"live": {
+		APIBaseURL: "https://iam-api.retailsvc.com/v1",
+		SchemaURL:  "https://iam-api.retailsvc.com/schemas/v1/openapi.json",
+	},
+}
 
+// Resolve returns the endpoints of the configured environment, with the API
+// base URL replaced by base_url when one is set
+func (r *URLResolver) Resolve() Endpoints {
 	// Default to live environment if not specified
 	env := r.environment
 	if env == "" {
 		env = "live"
 	}
 
-	return r.environmentToURL(env)
-}
-
-// environmentToURL maps environment names to their corresponding URLs
-func (r *URLResolver) environmentToURL(env string) string {
-	urls := map[string]string{
-		"test": "https://iam-api-test.retailsvc.com/schemas/v1/openapi.json",
-		"live": "https://iam-api.retailsvc.com/schemas/v1/openapi.json",
+	endpoints, ok := r.environments[env]
+	if !ok {
+		// This shouldn't happen after ValidateEnvironment, but included for safety
+		endpoints = r.environments["live"]
 	}
 
-	if url, ok := urls[env]; ok {
-		return url
+	// Base URL takes precedence if specified
+	if r.baseURL != "" {
+		endpoints.APIBaseURL = r.baseURL
 	}
 
-	// This shouldn't happen due to schema validation, but included for safety
-	return urls["live"]
+	return endpoints
+}
+
+// ResolveURL returns the API base URL based on configuration
+func (r *URLResolver) ResolveURL() string {
+	return r.Resolve().APIBaseURL
 }
 
 // ValidateEnvironment checks if the given environment is valid
@@ -55,12 +110,15 @@ func (r *URLResolver) ValidateEnvironment() error {
 		return nil // Empty is valid, will use default
 	}
 
-	validEnvs := []string{"test", "live"}
-	for _, env := range validEnvs {
-		if r.environment == env {
-			return nil
-		}
+	if _, ok := r.environments[r.environment]; ok {
+		return nil
 	}
 
-	return fmt.Errorf("invalid environment %q, valid values are: test, live", r.environment)
-}
\ No newline at end of file
+	validEnvs := make([]string, 0, len(r.environments))
+	for name := range r.environments {
+		validEnvs = append(validEnvs, name)
+	}
+	sort.Strings(validEnvs)
+
+	return fmt.Errorf("invalid environment %q, valid values are: %s", r.environment, strings.Join(validEnvs, ", "))
+}
@@ -17,19 +17,19 @@ func TestURLResolver(t *testing.T) {
 			name:        "Default to live environment",
 			environment: "",
 			baseURL:     "",
-			want:        "https://iam-api.retailsvc.com/schemas/v1/openapi.json",
+			want:        "https://iam-api.retailsvc.com/v1",
 		},
 		{
 			name:        "Test environment",
 			environment: "test",
 			baseURL:     "",
-			want:        "https://iam-api-test.retailsvc.com/schemas/v1/openapi.json",
+			want:        "https://iam-api-test.retailsvc.com/v1",
 		},
 		{
 			name:        "Live environment",
 			environment: "live",
 			baseURL:     "",
-			want:        "https://iam-api.retailsvc.com/schemas/v1/openapi.json",
+			want:        "https://iam-api.retailsvc.com/v1",
 		},
 		{
 			name:        "Base URL takes precedence",
@@ -48,6 +48,56 @@ func TestURLResolver(t *testing.T) {
 	}
 }
 
+func TestURLResolver_Resolve(t *testing.T) {
+	assert.Equal(t, Endpoints{
+		APIBaseURL: "https://iam-api.retailsvc.com/v1",
+		SchemaURL:  "https://iam-api.retailsvc.com/schemas/v1/openapi.json",
+	}, NewURLResolver("", "").Resolve())
+
+	assert.Equal(t, Endpoints{
+		APIBaseURL: "https://iam-api-test.retailsvc.com/v1",
+		SchemaURL:  "https://iam-api-test.retailsvc.com/schemas/v1/openapi.json",
+	}, NewURLResolver("test", "").Resolve())
+
+	// base_url only replaces the API host, not the schema endpoint
+	assert.Equal(t, Endpoints{
+		APIBaseURL: "https://custom-api.example.com",
+		SchemaURL:  "https://iam-api-test.retailsvc.com/schemas/v1/openapi.json",
+	}, NewURLResolver("test", "https://custom-api.example.com").Resolve())
+}
+
+func TestURLResolver_AddEnvironment(t *testing.T) {
+	resolver := NewURLResolver("staging", "")
+
+	// Unknown environments are invalid until registered
+	assert.Error(t, resolver.ValidateEnvironment())
+
+	assert.NoError(t, resolver.AddEnvironment("staging", Endpoints{
+		APIBaseURL: "https://iam-api-staging.retailsvc.com/v1",
+	}))
+	assert.NoError(t, resolver.ValidateEnvironment())
+	assert.Equal(t, Endpoints{
+		APIBaseURL: "https://iam-api-staging.retailsvc.com/v1",
+	}, resolver.Resolve())
+
+	// Overriding a built-in environment keeps the endpoints left unset
+	override := NewURLResolver("test", "")
+	assert.NoError(t, override.AddEnvironment("test", Endpoints{
+		TokenURL: "https://auth.example.com/oauth2/token",
+	}))
+	assert.Equal(t, Endpoints{
+		APIBaseURL: "https://iam-api-test.retailsvc.com/v1",
+		SchemaURL:  "https://iam-api-test.retailsvc.com/schemas/v1/openapi.json",
+		TokenURL:   "https://auth.example.com/oauth2/token",
+	}, override.Resolve())
+
+	// Other resolvers keep the built-in endpoints
+	assert.Empty(t, NewURLResolver("test", "").Resolve().TokenURL)
+
+	// New environments need an API base URL
+	assert.Error(t, resolver.AddEnvironment("eu", Endpoints{TokenURL: "https://auth.example.com/oauth2/token"}))
+}
+
 func TestURLResolver_ValidateEnvironment(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 )
@@ -72,4 +73,136 @@ func (v groupDescriptionValidator) ValidateString(_ context.Context, request val
 			),
 		)
 	}
-}
\ No newline at end of file
+}
+
+// permissionPattern matches permission identifiers of the form
+// <system>.<resource>.<action>, e.g. "pos.receipt.read"
+var permissionPattern = regexp.MustCompile(`^[a-z][a-z0-9]*\.[a-z][a-z0-9-]*\.[a-z][a-z0-9-]*$`)
+
+// permissionValidator validates an IAM permission identifier
+type permissionValidator struct {
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v permissionValidator) Description(_ context.Context) string {
+	return "permission must have the form <system>.<resource>.<action>, using lowercase letters, numbers and hyphens"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v permissionValidator) MarkdownDescription(_ context.Context) string {
+	return "Permission must have the form `<system>.<resource>.<action>` (e.g. `pos.receipt.read`), using lowercase letters, numbers and hyphens."
+}
+
+// ValidateString performs the validation
+func (v permissionValidator) ValidateString(_ context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue.ValueString()
+	if !permissionPattern.MatchString(value) {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid Permission",
+			fmt.Sprintf(
+				"Permission %q is invalid. Permissions must have the form <system>.<resource>.<action>, e.g. \"pos.receipt.read\", and contain only lowercase letters, numbers, and hyphens (-).",
+				value,
+			),
+		)
+	}
+}
+
+// regexValidator validates that a string is a valid regular expression
+type regexValidator struct {
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v regexValidator) Description(_ context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v regexValidator) MarkdownDescription(_ context.Context) string {
+	return "Value must be a valid [RE2](https://github.com/google/re2/wiki/Syntax) regular expression."
+}
+
+// ValidateString performs the validation
+func (v regexValidator) ValidateString(_ context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(request.ConfigValue.ValueString()); err != nil {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid Regular Expression",
+			fmt.Sprintf("Value %q is not a valid regular expression: %s", request.ConfigValue.ValueString(), err),
+		)
+	}
+}
+
+// environmentNamePattern matches environment names such as "live" or "eu-staging"
+var environmentNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// environmentNameValidator validates the name of a HiiRetail environment
+type environmentNameValidator struct {
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v environmentNameValidator) Description(_ context.Context) string {
+	return "environment name must start with a lowercase letter and contain only lowercase letters, numbers, hyphens, and underscores"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v environmentNameValidator) MarkdownDescription(_ context.Context) string {
+	return "Environment name must start with a lowercase letter and contain only lowercase letters, numbers, hyphens (`-`), and underscores (`_`)."
+}
+
+// ValidateString performs the validation
+func (v environmentNameValidator) ValidateString(_ context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue.ValueString()
+	if !environmentNamePattern.MatchString(value) {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid Environment Name",
+			fmt.Sprintf(
+				"Environment name %q is invalid. Environment names must start with a lowercase letter and contain only lowercase letters, numbers, hyphens (-), and underscores (_).",
+				value,
+			),
+		)
+	}
+}
+
+// durationValidator validates a positive Go duration string such as "30s" or "10m"
+type durationValidator struct {
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v durationValidator) Description(_ context.Context) string {
+	return "value must be a positive duration such as \"30s\", \"10m\" or \"1h30m\""
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v durationValidator) MarkdownDescription(_ context.Context) string {
+	return "Value must be a positive duration such as `30s`, `10m` or `1h30m`."
+}
+
+// ValidateString performs the validation
+func (v durationValidator) ValidateString(_ context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue.ValueString()
+	if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid Duration",
+			fmt.Sprintf("Value %q is not a valid duration. Use a positive number with a unit, such as \"30s\", \"10m\" or \"1h30m\".", value),
+		)
+	}
+}